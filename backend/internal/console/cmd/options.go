@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/joho/godotenv"
+	"github.com/mehmetali10/task-planner/internal/console/input"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// configFile is the console config file, flags and environment variables take precedence over it
+	configFile string
+	// providersFile is the file configuring the providers, they are asked for when it is not given
+	providersFile string
+	// enabledNames are the providers to run, the URLs of the providers when there is no providers file
+	enabledNames []string
+	// nonInteractive never asks for anything, missing values take their defaults
+	nonInteractive bool
+	// assumeYes answers yes to the questions of the console, such as using the default providers
+	assumeYes bool
+)
+
+// consoleConfig is the console config file, in YAML or JSON.
+type consoleConfig struct {
+	Database struct {
+		Host     string `yaml:"host"`
+		Port     int    `yaml:"port"`
+		User     string `yaml:"user"`
+		Password string `yaml:"password"`
+		Name     string `yaml:"name"`
+	} `yaml:"database"`
+	// ProvidersFile is relative to the directory of the config file.
+	ProvidersFile string `yaml:"providersFile"`
+	// Providers are the providers to run, as --provider.
+	Providers      []string `yaml:"providers"`
+	NonInteractive bool     `yaml:"nonInteractive"`
+	Yes            bool     `yaml:"yes"`
+}
+
+// dbSetting is a database setting of the console. It is read from its flag,
+// its environment variable or the console config file, the first one having
+// it wins. Without any, it is asked for when the console is interactive and
+// takes its default otherwise.
+type dbSetting struct {
+	env        string
+	flag       string
+	def        string
+	usage      string
+	value      string
+	fromConfig func(c *consoleConfig) string
+}
+
+var dbSettings = []*dbSetting{
+	{env: "DB_HOST", flag: "db-host", def: "my_postgres", usage: "Database host", fromConfig: func(c *consoleConfig) string { return c.Database.Host }},
+	{env: "DB_PORT", flag: "db-port", def: "5432", usage: "Database port", fromConfig: func(c *consoleConfig) string {
+		if c.Database.Port == 0 {
+			return ""
+		}
+		return strconv.Itoa(c.Database.Port)
+	}},
+	{env: "DB_USER", flag: "db-user", def: "postgres", usage: "Database user", fromConfig: func(c *consoleConfig) string { return c.Database.User }},
+	{env: "DB_PASSWORD", flag: "db-password", def: "pass", usage: "Database password", fromConfig: func(c *consoleConfig) string { return c.Database.Password }},
+	{env: "DB_NAME", flag: "db-name", def: "task", usage: "Database name", fromConfig: func(c *consoleConfig) string { return c.Database.Name }},
+}
+
+// options are the settings of the start command other than the database ones.
+type options struct {
+	// interactive is set when questions can be asked on a terminal
+	interactive   bool
+	yes           bool
+	providersFile string
+	providers     []string
+}
+
+// loadOptions merges the flags of the start command with the console config file.
+func loadOptions(cmd *cobra.Command) (*consoleConfig, options, error) {
+	conf := &consoleConfig{}
+	if configFile != "" {
+		var err error
+		if conf, err = loadConsoleConfig(configFile); err != nil {
+			return nil, options{}, err
+		}
+	}
+
+	opts := options{
+		interactive:   !nonInteractive && !conf.NonInteractive && input.IsTerminal(),
+		yes:           assumeYes || conf.Yes,
+		providersFile: conf.ProvidersFile,
+		providers:     conf.Providers,
+	}
+	if cmd.Flags().Changed("providers-file") {
+		opts.providersFile = providersFile
+	}
+	if cmd.Flags().Changed("provider") {
+		opts.providers = enabledNames
+	}
+	return conf, opts, nil
+}
+
+func loadConsoleConfig(file string) (*consoleConfig, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	conf := &consoleConfig{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(conf); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %s: %w", file, err)
+	}
+	if conf.ProvidersFile != "" && !filepath.IsAbs(conf.ProvidersFile) {
+		conf.ProvidersFile = filepath.Join(filepath.Dir(file), conf.ProvidersFile)
+	}
+	return conf, nil
+}
+
+// setupEnvironment sets the environment variables of the database settings.
+func setupEnvironment(cmd *cobra.Command, conf *consoleConfig, opts options) error {
+	// Values of a .env file count as environment variables
+	_ = godotenv.Load()
+
+	for _, setting := range dbSettings {
+		value, source := setting.value, "--"+setting.flag
+		if !cmd.Flags().Changed(setting.flag) {
+			value, source = os.Getenv(setting.env), setting.env
+		}
+		if value == "" {
+			value, source = setting.fromConfig(conf), "config file"
+		}
+		if value == "" {
+			if opts.interactive {
+				value = input.PromptForEnv(setting.env, setting.def)
+			} else {
+				value = setting.def
+			}
+		}
+
+		if !input.IsValidEnvVar(setting.env, value) {
+			return fmt.Errorf("invalid %s from %s: %q", setting.env, source, value)
+		}
+		os.Setenv(setting.env, value)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "console.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+database:
+  host: config-host
+  port: 6543
+  user: config-user
+providersFile: providers.yaml
+providers: [tracker]
+nonInteractive: true
+`), 0o600))
+
+	for _, setting := range dbSettings {
+		t.Setenv(setting.env, "")
+	}
+	t.Setenv("DB_USER", "env-user")
+
+	require.NoError(t, startCmd.ParseFlags([]string{"--config", file, "--db-host", "flag-host", "--provider", "other"}))
+	t.Cleanup(func() {
+		configFile, enabledNames = "", nil
+		for _, setting := range dbSettings {
+			setting.value = ""
+			startCmd.Flags().Lookup(setting.flag).Changed = false
+		}
+		startCmd.Flags().Lookup("config").Changed = false
+		startCmd.Flags().Lookup("provider").Changed = false
+	})
+
+	conf, opts, err := loadOptions(startCmd)
+	require.NoError(t, err)
+	require.False(t, opts.interactive)
+	require.Equal(t, filepath.Join(dir, "providers.yaml"), opts.providersFile)
+	require.Equal(t, []string{"other"}, opts.providers)
+
+	require.NoError(t, setupEnvironment(startCmd, conf, opts))
+	// Flags win over the environment, which wins over the config file, missing values take their defaults
+	require.Equal(t, "flag-host", os.Getenv("DB_HOST"))
+	require.Equal(t, "env-user", os.Getenv("DB_USER"))
+	require.Equal(t, "6543", os.Getenv("DB_PORT"))
+	require.Equal(t, "task", os.Getenv("DB_NAME"))
+}
@@ -22,60 +22,82 @@ import (
 var startCmd = &cobra.Command{
 	Use:   "start",
 	Short: "Start the task planner application",
-	Long:  `Start the task planner application, run migrations, and process tasks from providers.`,
+	Long: `Start the task planner application, run migrations, and process tasks from providers.
+
+Settings are read from flags, environment variables and the console config file, in this
+order. Missing settings are asked for when a terminal is attached, unless --non-interactive
+is given, and take their defaults otherwise.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		logger := log.NewLogger("cli", "error")
 
-		setupEnvironment()
+		conf, opts, err := loadOptions(cmd)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
 
-		if err := config.LoadConfig(); err != nil {
+		if err := setupEnvironment(cmd, conf, opts); err != nil {
 			logger.Fatal(err.Error())
 		}
 
-		logger.Info("Running migrations...")
-		migrate.MigrateAndSeed()
+		if err := config.LoadConfig(); err != nil {
+			logger.Fatal(err.Error())
+		}
 
-		providers := getProviders()
+		providers, err := getProviders(opts)
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
 		if len(providers) == 0 {
 			logger.Fatal("No providers specified.")
 		}
 
+		logger.Info("Running migrations...")
+		migrate.MigrateAndSeed()
+
 		repo := postgresRepo.NewPostgresRepo()
 		wp := worker.NewWorkerPool(len(providers), repo)
 		ctx, cancel := context.WithCancel(context.Background())
 		wp.Start(ctx)
 
-		processProviders(providers, logger, wp)
+		processing := processProviders(ctx, providers, logger, wp)
 
-		handleShutdown(cancel, wp, logger)
+		handleShutdown(cancel, processing, wp, logger)
 	},
 }
 
-func setupEnvironment() {
-	envVars := map[string]string{
-		// "DB_HOST": "localhost",
-		"DB_HOST":     "my_postgres",
-		"DB_PORT":     "5432",
-		"DB_USER":     "postgres",
-		"DB_PASSWORD": "pass",
-		"DB_NAME":     "task",
+// getProviders creates the providers of the providers file enabled with --provider, all of
+// them by default. Without a providers file they are asked for, unless given with --provider.
+// The default providers are used when confirmed, or with --yes.
+func getProviders(opts options) ([]pvd.Provider, error) {
+	if opts.providersFile != "" {
+		configs, err := pvd.LoadConfigs(opts.providersFile)
+		if err != nil {
+			return nil, err
+		}
+		return enabledProviders(configs, opts.providers)
 	}
 
-	for key, defaultVal := range envVars {
-		value := input.PromptForEnv(key, defaultVal)
-		os.Setenv(key, value)
+	if len(opts.providers) > 0 {
+		var configs []pvd.Config
+		for _, url := range opts.providers {
+			configs = append(configs, pvd.Config{URL: url})
+		}
+		return enabledProviders(configs, nil)
 	}
-}
 
-func getProviders() []string {
-	defaultProviders := []string{
-		"https://raw.githubusercontent.com/WEG-Technology/mock/refs/heads/main/mock-one",
-		"https://raw.githubusercontent.com/WEG-Technology/mock/refs/heads/main/mock-two",
+	useDefaults := opts.yes
+	if !useDefaults && opts.interactive {
+		useDefaults = input.PromptYesNo(fmt.Sprintf("Do you want to use the default providers?\n 1-) %s\n 2-) %s\n (yes/no)", pvd.DefaultURLs[0], pvd.DefaultURLs[1]))
 	}
-
-	var providers []string
-	if input.PromptYesNo(fmt.Sprintf("Do you want to use the default providers?\n 1-) %s\n 2-) %s\n (yes/no)", defaultProviders[0], defaultProviders[1])) {
-		providers = append(providers, defaultProviders...)
+	var configs []pvd.Config
+	if useDefaults {
+		for _, url := range pvd.DefaultURLs {
+			configs = append(configs, pvd.Config{URL: url})
+		}
+	}
+	providers, err := enabledProviders(configs, nil)
+	if err != nil || !opts.interactive {
+		return providers, err
 	}
 
 	for {
@@ -83,21 +105,57 @@ func getProviders() []string {
 		if newProvider == "" {
 			break
 		}
-		providers = append(providers, newProvider)
+		provider, err := pvd.New(pvd.Config{URL: newProvider})
+		if err != nil {
+			fmt.Printf("Invalid provider: %v. Please enter an http, https or file URL.\n", err)
+			continue
+		}
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+// enabledProviders creates the providers with the given names, or all of them when no names are given.
+func enabledProviders(configs []pvd.Config, names []string) ([]pvd.Provider, error) {
+	var providers []pvd.Provider
+	for _, cfg := range configs {
+		provider, err := pvd.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+	if len(names) == 0 {
+		return providers, nil
 	}
 
-	return providers
+	byName := map[string]pvd.Provider{}
+	for _, provider := range providers {
+		byName[provider.Name()] = provider
+	}
+	var enabled []pvd.Provider
+	for _, name := range names {
+		provider, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown provider %q", name)
+		}
+		enabled = append(enabled, provider)
+	}
+	return enabled, nil
 }
 
-func processProviders(providers []string, logger log.Logger, wp *worker.WorkerPool) {
+// processProviders processes the providers in the background, the returned
+// WaitGroup is done once all of them are.
+func processProviders(ctx context.Context, providers []pvd.Provider, logger log.Logger, wp *worker.WorkerPool) *sync.WaitGroup {
 	var wg sync.WaitGroup
 	wg.Add(len(providers))
 
 	for _, provider := range providers {
-		go func(provider string) {
+		go func(provider pvd.Provider) {
 			defer wg.Done()
-			if err := pvd.FetchAndProcessTasks(provider, logger, wp); err != nil {
-				logger.Error("Error processing tasks from provider %s: %v", provider, err)
+			if err := pvd.Process(ctx, provider, logger, wp); err != nil {
+				logger.Error("Error processing tasks from provider %s: %v", provider.Name(), err)
 			}
 		}(provider)
 	}
@@ -109,19 +167,31 @@ func processProviders(providers []string, logger log.Logger, wp *worker.WorkerPo
 		logger.Info("Worker pool fully stopped. Exiting application.")
 		os.Exit(0)
 	}()
+	return &wg
 }
 
-func handleShutdown(cancel context.CancelFunc, wp *worker.WorkerPool, logger log.Logger) {
+// handleShutdown stops the providers on a signal, and the worker pool once they
+// no longer submit tasks.
+func handleShutdown(cancel context.CancelFunc, processing *sync.WaitGroup, wp *worker.WorkerPool, logger log.Logger) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 
 	<-sigChan
 	logger.Info("Received termination signal, shutting down...")
 	cancel()
+	processing.Wait()
 	wp.Stop()
 	logger.Info("Application terminated gracefully.")
 }
 
 func init() {
+	startCmd.Flags().StringVar(&configFile, "config", "", "YAML or JSON console config file with the database settings and providers")
+	startCmd.Flags().StringSliceVar(&enabledNames, "provider", nil, "Name of a provider of the providers file to run, all of them by default. Without a providers file, the URL of a provider")
+	startCmd.Flags().StringVar(&providersFile, "providers-file", "", "YAML or JSON file configuring the providers and how their tasks are mapped")
+	startCmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "Never ask for missing settings, use their defaults")
+	startCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Answer yes to questions, such as using the default providers")
+	for _, setting := range dbSettings {
+		startCmd.Flags().StringVar(&setting.value, setting.flag, "", fmt.Sprintf("%s, or the %s environment variable (default %q)", setting.usage, setting.env, setting.def))
+	}
 	rootCmd.AddCommand(startCmd)
 }
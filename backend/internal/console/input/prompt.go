@@ -60,3 +60,16 @@ func PromptYesNo(message string) bool {
 		fmt.Println("Please enter 'yes' or 'no'.")
 	}
 }
+
+// IsTerminal tells whether the standard input is a terminal, so questions can be answered
+func IsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	// The null device is a character device as well, but nobody answers there
+	if null, err := os.Stat(os.DevNull); err == nil && os.SameFile(info, null) {
+		return false
+	}
+	return true
+}
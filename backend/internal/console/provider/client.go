@@ -0,0 +1,387 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout     = 30 * time.Second
+	defaultMaxAttempts = 4
+	defaultBackoff     = 500 * time.Millisecond
+	defaultMaxBackoff  = 30 * time.Second
+	defaultAPIKeyName  = "X-API-Key"
+	defaultMaxBodySize = 256 << 20
+)
+
+// Kinds of authentication of an http provider.
+const (
+	AuthBearer = "bearer"
+	AuthBasic  = "basic"
+	AuthAPIKey = "apiKey"
+)
+
+// HTTPConfig configures the client of an http provider. Values of the
+// authentication and the headers may refer to environment variables as
+// ${NAME}, so secrets need not be written in the providers file.
+type HTTPConfig struct {
+	// Timeout of a request until its response arrives, and of every read of
+	// the response after. Time spent processing the tasks read does not
+	// count, so large responses can take longer. 30s by default.
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxBodySize limits the size of a response, 256MiB by default. Tasks
+	// read before a response turns out too large are still created.
+	MaxBodySize ByteSize `yaml:"maxBodySize"`
+	// MaxAttempts of a request failing with a network error, a 5xx or a 429
+	// response. 4 by default, 1 disables retries.
+	MaxAttempts int `yaml:"maxAttempts"`
+	// Backoff is the longest wait before the first retry, it doubles for every
+	// later one up to MaxBackoff. Waits are random up to the backoff, unless
+	// the response tells how long to wait with Retry-After.
+	Backoff    time.Duration     `yaml:"backoff"`
+	MaxBackoff time.Duration     `yaml:"maxBackoff"`
+	Auth       *Auth             `yaml:"auth"`
+	Headers    map[string]string `yaml:"headers"`
+	// CacheFile keeps the ETag and Last-Modified of the responses, so later
+	// runs only read them again when they changed.
+	CacheFile string `yaml:"cacheFile"`
+}
+
+// Auth authenticates the requests of an http provider.
+type Auth struct {
+	// Type is bearer, basic or apiKey.
+	Type     string `yaml:"type"`
+	Token    string `yaml:"token"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	Key      string `yaml:"key"`
+	// Header sending an API key, X-API-Key by default.
+	Header string `yaml:"header"`
+	// Query parameter sending an API key instead of a header.
+	Query string `yaml:"query"`
+}
+
+// retryError marks the errors of attempts that can be retried.
+type retryError struct {
+	err error
+	// after is the wait asked for by the response, zero when it did not ask
+	after time.Duration
+}
+
+func (e *retryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryError) Unwrap() error {
+	return e.err
+}
+
+// client sends the requests of an http provider.
+type client struct {
+	cfg     HTTPConfig
+	headers http.Header
+	query   url.Values
+	http    *http.Client
+	// sleep waits between attempts, or until ctx is done
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newClient(cfg HTTPConfig) (*client, error) {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.Backoff == 0 {
+		cfg.Backoff = defaultBackoff
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = defaultMaxBackoff
+	}
+	if cfg.MaxBodySize == 0 {
+		cfg.MaxBodySize = defaultMaxBodySize
+	}
+	if cfg.Timeout < 0 || cfg.MaxAttempts < 0 || cfg.Backoff < 0 || cfg.MaxBackoff < cfg.Backoff {
+		return nil, errors.New("http: timeout, maxAttempts and backoff must be positive and maxBackoff at least the backoff")
+	}
+	if cfg.MaxBodySize < 0 {
+		return nil, errors.New("http: maxBodySize must be positive")
+	}
+
+	c := &client{cfg: cfg, headers: http.Header{}, query: url.Values{}, http: &http.Client{}, sleep: sleep}
+	c.headers.Set("Accept", "application/json")
+	for name, value := range cfg.Headers {
+		c.headers.Set(name, os.ExpandEnv(value))
+	}
+
+	if auth := cfg.Auth; auth != nil {
+		switch auth.Type {
+		case AuthBearer:
+			if auth.Token == "" {
+				return nil, errors.New("http: bearer authentication needs a token")
+			}
+			c.headers.Set("Authorization", "Bearer "+os.ExpandEnv(auth.Token))
+		case AuthBasic:
+			if auth.Username == "" {
+				return nil, errors.New("http: basic authentication needs a username")
+			}
+			req := http.Request{Header: http.Header{}}
+			req.SetBasicAuth(os.ExpandEnv(auth.Username), os.ExpandEnv(auth.Password))
+			c.headers.Set("Authorization", req.Header.Get("Authorization"))
+		case AuthAPIKey:
+			if auth.Key == "" {
+				return nil, errors.New("http: apiKey authentication needs a key")
+			}
+			if auth.Query != "" {
+				c.query.Set(auth.Query, os.ExpandEnv(auth.Key))
+			} else {
+				header := auth.Header
+				if header == "" {
+					header = defaultAPIKeyName
+				}
+				c.headers.Set(header, os.ExpandEnv(auth.Key))
+			}
+		default:
+			return nil, fmt.Errorf("http: unknown authentication %q, expected bearer, basic or apiKey", auth.Type)
+		}
+	}
+	return c, nil
+}
+
+// get fetches rawURL, retrying failed attempts. The response is either 200 OK
+// or, for conditional requests, 304 Not Modified. Its body is limited to
+// MaxBodySize and must be closed.
+func (c *client) get(ctx context.Context, rawURL string, validators cacheEntry) (*http.Response, error) {
+	var err error
+	for attempt := 0; attempt < c.cfg.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			wait := c.backoff(attempt)
+			var retryErr *retryError
+			if errors.As(err, &retryErr) && retryErr.after > 0 {
+				wait = retryErr.after
+			}
+			if err := c.sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+
+		var resp *http.Response
+		resp, err = c.attempt(ctx, rawURL, validators)
+		if err == nil {
+			return resp, nil
+		}
+		var retryErr *retryError
+		if !errors.As(err, &retryErr) || ctx.Err() != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w, gave up after %d attempts", err, c.cfg.MaxAttempts)
+}
+
+func (c *client) attempt(ctx context.Context, rawURL string, validators cacheEntry) (*http.Response, error) {
+	// The timeout is restarted by every read of the body
+	parent := ctx
+	ctx, cancel := context.WithCancelCause(ctx)
+	timeoutErr := fmt.Errorf("no response within %s: %w", c.cfg.Timeout, context.DeadlineExceeded)
+	timer := time.AfterFunc(c.cfg.Timeout, func() { cancel(timeoutErr) })
+	stop := func() {
+		timer.Stop()
+		cancel(context.Canceled)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		stop()
+		return nil, fmt.Errorf("failed to fetch data from %s: %w", rawURL, err)
+	}
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+	if len(c.query) > 0 {
+		query := req.URL.Query()
+		for name, values := range c.query {
+			query[name] = values
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		if context.Cause(ctx) == timeoutErr {
+			err = timeoutErr
+		}
+		stop()
+		err = fmt.Errorf("failed to fetch data from %s: %w", rawURL, err)
+		if parent.Err() != nil {
+			// Cancelled rather than failed
+			return nil, err
+		}
+		return nil, &retryError{err: err}
+	}
+	timer.Stop()
+
+	switch {
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotModified:
+		if resp.ContentLength > int64(c.cfg.MaxBodySize) {
+			resp.Body.Close()
+			stop()
+			return nil, fmt.Errorf("response from %s is larger than the maximum of %d bytes", rawURL, c.cfg.MaxBodySize)
+		}
+		resp.Body = &timeoutBody{
+			body:    resp.Body,
+			r:       &limitedReader{r: resp.Body, max: int64(c.cfg.MaxBodySize)},
+			ctx:     ctx,
+			timer:   timer,
+			timeout: c.cfg.Timeout,
+			stop:    stop,
+		}
+		return resp, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		after := retryAfter(resp.Header.Get("Retry-After"), time.Now())
+		resp.Body.Close()
+		stop()
+		return nil, &retryError{err: fmt.Errorf("non-200 response from %s: %d", rawURL, resp.StatusCode), after: after}
+	default:
+		resp.Body.Close()
+		stop()
+		return nil, fmt.Errorf("non-200 response from %s: %d", rawURL, resp.StatusCode)
+	}
+}
+
+// backoff returns a random wait before an attempt, up to a backoff doubling
+// with every attempt.
+func (c *client) backoff(attempt int) time.Duration {
+	limit := c.cfg.MaxBackoff
+	if shift := attempt - 1; shift < 32 && c.cfg.Backoff<<shift < limit {
+		limit = c.cfg.Backoff << shift
+	}
+	return time.Duration(rand.Int63n(int64(limit) + 1))
+}
+
+// retryAfter reads a Retry-After header, given in seconds or as a date.
+func retryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// timeoutBody is the body of a response, every read of it has the timeout of
+// the request.
+type timeoutBody struct {
+	body    io.Closer
+	r       io.Reader
+	ctx     context.Context
+	timer   *time.Timer
+	timeout time.Duration
+	stop    func()
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	b.timer.Reset(b.timeout)
+	n, err := b.r.Read(p)
+	b.timer.Stop()
+	if err != nil && err != io.EOF && b.ctx.Err() != nil {
+		err = context.Cause(b.ctx)
+	}
+	return n, err
+}
+
+func (b *timeoutBody) Close() error {
+	defer b.stop()
+	return b.body.Close()
+}
+
+// cacheEntry holds the validators of the response of a URL.
+type cacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// cacheMu guards the cache files, which providers may share.
+var cacheMu sync.Mutex
+
+// readCache returns the validators of rawURL kept in file.
+func readCache(file, rawURL string) (cacheEntry, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	entries, err := loadCache(file)
+	return entries[rawURL], err
+}
+
+// writeCache keeps the validators of rawURL in file.
+func writeCache(file, rawURL string, entry cacheEntry) error {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	entries, err := loadCache(file)
+	if err != nil {
+		return err
+	}
+	if entry == (cacheEntry{}) {
+		delete(entries, rawURL)
+	} else {
+		entries[rawURL] = entry
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Written aside first, so a crash does not leave half a file
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cache file: %w", err)
+	}
+	return os.Rename(tmp, file)
+}
+
+func loadCache(file string) (map[string]cacheEntry, error) {
+	entries := map[string]cacheEntry{}
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache file: %w", err)
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid cache file %s: %w", file, err)
+	}
+	return entries, nil
+}
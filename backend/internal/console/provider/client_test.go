@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/console/worker"
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+	"github.com/mehmetali10/task-planner/pkg/log"
+	"github.com/stretchr/testify/require"
+)
+
+// waits makes c record its waits between attempts instead of sleeping.
+func waits(c *client) *[]time.Duration {
+	var waits []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return &waits
+}
+
+func TestClientRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	c, err := newClient(HTTPConfig{Backoff: time.Second, MaxBackoff: 4 * time.Second})
+	require.NoError(t, err)
+	slept := waits(c)
+	resp, err := c.get(context.Background(), server.URL, cacheEntry{})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, int32(3), calls.Load())
+	require.Len(t, *slept, 2)
+	require.LessOrEqual(t, (*slept)[0], time.Second)
+	require.Equal(t, 7*time.Second, (*slept)[1])
+
+	// Other errors are not retried, and retries end after the last attempt
+	calls.Store(10)
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	_, err = c.get(context.Background(), server.URL+"/missing", cacheEntry{})
+	require.EqualError(t, err, "non-200 response from "+server.URL+"/missing: 404")
+	require.Equal(t, int32(11), calls.Load())
+
+	_, err = c.get(context.Background(), server.URL, cacheEntry{})
+	require.ErrorContains(t, err, "502, gave up after 4 attempts")
+	require.Equal(t, int32(15), calls.Load())
+}
+
+func TestClientBackoff(t *testing.T) {
+	c, err := newClient(HTTPConfig{Backoff: time.Second, MaxBackoff: 5 * time.Second})
+	require.NoError(t, err)
+	for attempt, limit := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 60: 5 * time.Second} {
+		for i := 0; i < 20; i++ {
+			wait := c.backoff(attempt)
+			require.GreaterOrEqual(t, wait, time.Duration(0))
+			require.LessOrEqual(t, wait, limit)
+		}
+	}
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, 3*time.Second, retryAfter("3", now))
+	require.Equal(t, time.Minute, retryAfter("Wed, 01 May 2024 12:01:00 GMT", now))
+	require.Zero(t, retryAfter("Wed, 01 May 2024 11:00:00 GMT", now))
+	require.Zero(t, retryAfter("soon", now))
+}
+
+func TestClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	c, err := newClient(HTTPConfig{Timeout: 20 * time.Millisecond, MaxAttempts: 2})
+	require.NoError(t, err)
+	slept := waits(c)
+	_, err = c.get(context.Background(), server.URL, cacheEntry{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, *slept, 1)
+
+	// A cancelled fetch is not retried
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	*slept = nil
+	_, err = c.get(ctx, server.URL, cacheEntry{})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, *slept)
+}
+
+func TestClientAuth(t *testing.T) {
+	var req *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req = r
+	}))
+	defer server.Close()
+
+	t.Setenv("TRACKER_TOKEN", "secret")
+	for _, tc := range []struct {
+		cfg    HTTPConfig
+		header string
+		value  string
+	}{
+		{HTTPConfig{Auth: &Auth{Type: AuthBearer, Token: "${TRACKER_TOKEN}"}}, "Authorization", "Bearer secret"},
+		{HTTPConfig{Auth: &Auth{Type: AuthBasic, Username: "me", Password: "$TRACKER_TOKEN"}}, "Authorization", "Basic bWU6c2VjcmV0"},
+		{HTTPConfig{Auth: &Auth{Type: AuthAPIKey, Key: "${TRACKER_TOKEN}"}}, "X-Api-Key", "secret"},
+		{HTTPConfig{Auth: &Auth{Type: AuthAPIKey, Header: "Api-Token", Key: "k"}}, "Api-Token", "k"},
+		{HTTPConfig{Headers: map[string]string{"X-Tenant": "acme", "Accept": "application/vnd.tracker+json"}}, "Accept", "application/vnd.tracker+json"},
+	} {
+		c, err := newClient(tc.cfg)
+		require.NoError(t, err)
+		resp, err := c.get(context.Background(), server.URL, cacheEntry{})
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, tc.value, req.Header.Get(tc.header), tc.header)
+	}
+
+	c, err := newClient(HTTPConfig{Auth: &Auth{Type: AuthAPIKey, Query: "api_key", Key: "k"}})
+	require.NoError(t, err)
+	resp, err := c.get(context.Background(), server.URL+"?page=2", cacheEntry{})
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, "k", req.URL.Query().Get("api_key"))
+	require.Equal(t, "2", req.URL.Query().Get("page"))
+
+	for _, cfg := range []HTTPConfig{
+		{Auth: &Auth{Type: "oauth"}},
+		{Auth: &Auth{Type: AuthBearer}},
+		{Auth: &Auth{Type: AuthBasic}},
+		{Auth: &Auth{Type: AuthAPIKey}},
+		{Timeout: -time.Second},
+		{Backoff: time.Minute, MaxBackoff: time.Second},
+	} {
+		_, err := newClient(cfg)
+		require.Error(t, err)
+	}
+}
+
+// upsertRepo saves tasks by calling upsert.
+type upsertRepo struct {
+	repository.Repository
+	upsert func(reqs []payload.CreateTaskRequest) error
+}
+
+func (r *upsertRepo) UpsertTasks(ctx context.Context, reqs []payload.CreateTaskRequest) ([]payload.TaskImportResult, error) {
+	if err := r.upsert(reqs); err != nil {
+		return nil, err
+	}
+	results := make([]payload.TaskImportResult, len(reqs))
+	for i, req := range reqs {
+		results[i] = payload.TaskImportResult{Index: i, ExternalID: req.ExternalID, Provider: req.Provider, Status: payload.ImportCreated}
+	}
+	return results, nil
+}
+
+func TestHTTPProviderConditional(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 01 May 2024 12:00:00 GMT")
+		w.Write([]byte(`[{"id": 1, "zorluk": 3, "sure": 5}]`))
+	}))
+	defer server.Close()
+
+	cfg := Config{URL: server.URL, HTTP: HTTPConfig{CacheFile: filepath.Join(t.TempDir(), "cache.json")}}
+	require.NoError(t, cfg.compile())
+	p, err := New(cfg)
+	require.NoError(t, err)
+
+	var saved []payload.CreateTaskRequest
+	saveErr := errors.New("database is down")
+	repo := &upsertRepo{upsert: func(reqs []payload.CreateTaskRequest) error {
+		if saveErr != nil {
+			return saveErr
+		}
+		saved = append(saved, reqs...)
+		return nil
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wp := worker.NewWorkerPool(1, repo)
+	wp.Start(ctx)
+	logger := log.NewLogger("test", "fatal")
+
+	// Tasks that failed to be saved are fetched again in full
+	require.NoError(t, Process(ctx, p, logger, wp))
+	require.Empty(t, requests[0].Header.Get("If-None-Match"))
+	saveErr = nil
+	require.NoError(t, Process(ctx, p, logger, wp))
+	require.Empty(t, requests[1].Header.Get("If-None-Match"))
+	require.Len(t, saved, 1)
+
+	// Once saved, unchanged tasks are not read again
+	require.NoError(t, Process(ctx, p, logger, wp))
+	require.Equal(t, `"v1"`, requests[2].Header.Get("If-None-Match"))
+	require.Equal(t, "Wed, 01 May 2024 12:00:00 GMT", requests[2].Header.Get("If-Modified-Since"))
+	tasks, errs := collect(p)
+	require.Empty(t, errs)
+	require.Empty(t, tasks)
+	require.Len(t, saved, 1)
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultURLs are the providers offered when no providers file is given.
+var DefaultURLs = []string{
+	"https://raw.githubusercontent.com/WEG-Technology/mock/refs/heads/main/mock-one",
+	"https://raw.githubusercontent.com/WEG-Technology/mock/refs/heads/main/mock-two",
+}
+
+// Config configures a provider of tasks.
+type Config struct {
+	// Name identifies the tasks of the provider, it defaults to the URL or path.
+	Name string `yaml:"name"`
+	// Type is the registered type of the provider: file for file:// URLs, http otherwise.
+	Type string `yaml:"type"`
+	URL  string `yaml:"url"`
+	// Tasks is the path of the array of tasks in a JSON response or file, the whole document by default.
+	Tasks string `yaml:"tasks"`
+
+	// Path is the file or directory of a file provider.
+	Path string `yaml:"path"`
+	// Format of the files of a file provider: json, ndjson or csv. By default
+	// it is told by the extension of each file.
+	Format string `yaml:"format"`
+	// Watch keeps checking the directory of a file provider for new files every Interval.
+	Watch    bool          `yaml:"watch"`
+	Interval time.Duration `yaml:"interval"`
+
+	// HTTP configures the requests of an http provider.
+	HTTP HTTPConfig `yaml:"http"`
+
+	// Fields maps the tasks of the provider. Without it the formats of the
+	// default providers are recognised.
+	Fields *Mapping `yaml:"fields"`
+
+	tasks path
+}
+
+// configFile is the file configuring the providers, in YAML or JSON.
+type configFile struct {
+	Providers []Config `yaml:"providers"`
+}
+
+// LoadConfigs reads the providers of a providers file.
+func LoadConfigs(file string) ([]Config, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read providers file: %w", err)
+	}
+
+	// YAML is a superset of JSON, so both are read the same way
+	var content configFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&content); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid providers file %s: %w", file, err)
+	}
+	if len(content.Providers) == 0 {
+		return nil, fmt.Errorf("invalid providers file %s: no providers", file)
+	}
+
+	names := map[string]bool{}
+	for i := range content.Providers {
+		cfg := &content.Providers[i]
+		if err := cfg.compile(); err != nil {
+			return nil, fmt.Errorf("invalid providers file %s: provider %d: %w", file, i+1, err)
+		}
+		if names[cfg.Name] {
+			return nil, fmt.Errorf("invalid providers file %s: provider %q is given twice", file, cfg.Name)
+		}
+		names[cfg.Name] = true
+	}
+	return content.Providers, nil
+}
+
+func (c *Config) compile() error {
+	if c.Type == "" {
+		c.Type = "http"
+		if c.Path != "" || strings.HasPrefix(c.URL, "file://") {
+			c.Type = "file"
+		}
+	}
+	if c.Name == "" {
+		c.Name = c.URL
+	}
+	if c.Name == "" {
+		c.Name = c.Path
+	}
+	if c.Name == "" {
+		return errors.New("a name is required")
+	}
+
+	var err error
+	if c.tasks, err = parsePath(c.Tasks); err != nil {
+		return fmt.Errorf("tasks: %w", err)
+	}
+	if c.Fields != nil {
+		return c.Fields.compile()
+	}
+	return nil
+}
+
+// task maps raw task data to a CreateTaskRequest
+func (c *Config) task(raw interface{}) (payload.CreateTaskRequest, error) {
+	if c.Fields == nil {
+		return builtinTask(raw, c.Name)
+	}
+	return c.Fields.task(raw, c.Name)
+}
+
+// byteUnits are the units of a ByteSize.
+var byteUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"KB":  1000,
+	"MB":  1000 * 1000,
+	"GB":  1000 * 1000 * 1000,
+	"KiB": 1 << 10,
+	"MiB": 1 << 20,
+	"GiB": 1 << 30,
+}
+
+// ByteSize is a number of bytes, written alone or with a unit, e.g. 512KB or 64MiB.
+type ByteSize int64
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *ByteSize) UnmarshalYAML(value *yaml.Node) error {
+	text := strings.TrimSpace(value.Value)
+	end := strings.IndexFunc(text, func(r rune) bool { return r < '0' || r > '9' })
+	if end < 0 {
+		end = len(text)
+	}
+	n, err := strconv.ParseInt(text[:end], 10, 64)
+	unit, ok := byteUnits[strings.TrimSpace(text[end:])]
+	if value.Kind != yaml.ScalarNode || err != nil || !ok || n > math.MaxInt64/unit {
+		return fmt.Errorf("invalid size %q, expected bytes or a number with KB, MB, GB, KiB, MiB or GiB", value.Value)
+	}
+	*b = ByteSize(n * unit)
+	return nil
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	errNoTasks  = errors.New("no tasks")
+	errNotArray = errors.New("not an array")
+)
+
+// decodeTasks reads the array of tasks at p in the JSON document of r and
+// sends its tasks one at a time, as soon as each one is decoded. The rest of
+// the document is skipped token by token, so memory does not grow with the
+// size of the document. It returns errNoTasks when there is nothing at p and
+// errNotArray when the value at p is not an array.
+func decodeTasks(r io.Reader, p path, send sendFunc) error {
+	dec := json.NewDecoder(r)
+	for _, step := range p {
+		found, err := seek(dec, step)
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		if !found {
+			return errNoTasks
+		}
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	switch tok {
+	case nil:
+		return errNoTasks
+	case json.Delim('['):
+	default:
+		return errNotArray
+	}
+
+	for index := 0; dec.More(); index++ {
+		var raw interface{}
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+		if !send(index, raw, nil) {
+			return nil
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	return nil
+}
+
+// seek moves dec to the value of step in the next value of the document, the
+// key of an object or the index of an array. It returns false when there is
+// no such value.
+func seek(dec *json.Decoder, step pathStep) (bool, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return false, err
+	}
+
+	if step.key != "" {
+		if tok != json.Delim('{') {
+			return false, nil
+		}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return false, err
+			}
+			if key == step.key {
+				return true, nil
+			}
+			if err := skip(dec); err != nil {
+				return false, err
+			}
+		}
+		return false, nil
+	}
+
+	if tok != json.Delim('[') {
+		return false, nil
+	}
+	for i := 0; i < step.index && dec.More(); i++ {
+		if err := skip(dec); err != nil {
+			return false, err
+		}
+	}
+	return dec.More(), nil
+}
+
+// skip reads past the next value of dec.
+func skip(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// limitedReader reads at most max bytes, reading more fails rather than
+// ending a truncated document.
+type limitedReader struct {
+	r    io.Reader
+	max  int64
+	read int64
+}
+
+func (l *limitedReader) Read(b []byte) (int, error) {
+	if l.read > l.max {
+		return 0, fmt.Errorf("larger than the maximum of %d bytes", l.max)
+	}
+	if left := l.max + 1 - l.read; int64(len(b)) > left {
+		b = b[:left]
+	}
+	n, err := l.r.Read(b)
+	l.read += int64(n)
+	if l.read > l.max {
+		return n, fmt.Errorf("larger than the maximum of %d bytes", l.max)
+	}
+	return n, err
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// decodeAll decodes the tasks of doc at the path text.
+func decodeAll(t *testing.T, doc, text string) ([]interface{}, error) {
+	p, err := parsePath(text)
+	require.NoError(t, err)
+	var items []interface{}
+	err = decodeTasks(strings.NewReader(doc), p, func(index int, raw interface{}, err error) bool {
+		require.NoError(t, err)
+		require.Equal(t, len(items), index)
+		items = append(items, raw)
+		return true
+	})
+	return items, err
+}
+
+func TestDecodeTasks(t *testing.T) {
+	doc := `{"meta": {"pages": [1, 2], "next": null}, "data": [{"skip": [{"id": 0}]}, {"items": [{"id": 1}, {"id": 2, "tags": ["a"]}]}], "after": {"id": 3}}`
+
+	items, err := decodeAll(t, doc, "$.data[1].items")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"id": float64(1)},
+		map[string]interface{}{"id": float64(2), "tags": []interface{}{"a"}},
+	}, items)
+
+	items, err = decodeAll(t, `[{"id": 1}]`, "$")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	items, err = decodeAll(t, `{"tasks": []}`, "tasks")
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	for _, text := range []string{"$.missing", "$.data[2]", "$.meta.next", "$.meta.pages[0].id"} {
+		_, err = decodeAll(t, doc, text)
+		require.ErrorIs(t, err, errNoTasks, text)
+	}
+	for _, text := range []string{"$", "$.after", "$.meta.pages[0]"} {
+		_, err = decodeAll(t, doc, text)
+		require.ErrorIs(t, err, errNotArray, text)
+	}
+
+	// Tasks before a syntax error are sent
+	items, err = decodeAll(t, `{"tasks": [{"id": 1}, {"id": 2,]}`, "tasks")
+	require.ErrorContains(t, err, "invalid JSON")
+	require.Len(t, items, 1)
+	_, err = decodeAll(t, `{"tasks": [{"id": 1}`, "tasks")
+	require.ErrorContains(t, err, "invalid JSON")
+}
+
+func TestLimitedReader(t *testing.T) {
+	data, err := io.ReadAll(&limitedReader{r: strings.NewReader("12345"), max: 5})
+	require.NoError(t, err)
+	require.Equal(t, "12345", string(data))
+
+	_, err = io.ReadAll(&limitedReader{r: strings.NewReader("123456"), max: 5})
+	require.EqualError(t, err, "larger than the maximum of 5 bytes")
+}
+
+func TestByteSize(t *testing.T) {
+	for text, expected := range map[string]ByteSize{"512": 512, "10KB": 10000, "64MiB": 64 << 20, "1 GiB": 1 << 30} {
+		var size ByteSize
+		require.NoError(t, yaml.Unmarshal([]byte(text), &size), text)
+		require.Equal(t, expected, size, text)
+	}
+	for _, text := range []string{"MiB", "-1", "10TB", "1.5MB", "[1]"} {
+		var size ByteSize
+		require.Error(t, yaml.Unmarshal([]byte(text), &size), text)
+	}
+}
+
+func TestHTTPProviderStreaming(t *testing.T) {
+	// The response is written while its first tasks are already read
+	sent := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [{"id": 1, "zorluk": 3, "sure": 5}`))
+		w.(http.Flusher).Flush()
+		<-sent
+		for i := 2; i <= 1000; i++ {
+			fmt.Fprintf(w, `, {"id": %d, "zorluk": 3, "sure": 5}`, i)
+		}
+		w.Write([]byte(`]}`))
+	}))
+	defer server.Close()
+
+	cfg := Config{URL: server.URL, Tasks: "data"}
+	require.NoError(t, cfg.compile())
+	p, err := New(cfg)
+	require.NoError(t, err)
+	tasks, errs := p.Fetch(context.Background())
+	require.Equal(t, uint(1), (<-tasks).ExternalID)
+	close(sent)
+	count := 1
+	for range tasks {
+		count++
+	}
+	require.Empty(t, <-errs)
+	require.Equal(t, 1000, count)
+
+	// Responses larger than maxBodySize fail, whether their size is known or not
+	cfg.HTTP.MaxBodySize = 100
+	p, err = New(cfg)
+	require.NoError(t, err)
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [` + strings.Repeat(`{"id": 1, "zorluk": 3, "sure": 5}, `, 10) + `]}`))
+	})
+	_, errs2 := collect(p)
+	require.Len(t, errs2, 1)
+	require.ErrorContains(t, errs2[0], "larger than the maximum of 100 bytes")
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [`))
+		for i := 1; i <= 10; i++ {
+			fmt.Fprintf(w, `{"id": %d, "zorluk": 3, "sure": 5}, `, i)
+			w.(http.Flusher).Flush()
+		}
+	})
+	// Tasks read before are kept
+	read, errs2 := collect(p)
+	require.Len(t, errs2, 1)
+	require.ErrorContains(t, errs2[0], "larger than the maximum of 100 bytes")
+	require.Len(t, read, 2)
+}
+
+func TestHTTPProviderReadTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id": 1, "zorluk": 3, "sure": 5}`))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	cfg := Config{URL: server.URL, HTTP: HTTPConfig{Timeout: 50 * time.Millisecond}}
+	require.NoError(t, cfg.compile())
+	p, err := New(cfg)
+	require.NoError(t, err)
+	tasks, errs := p.Fetch(context.Background())
+
+	// Waiting for the task to be taken does not count
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, uint(1), (<-tasks).ExternalID)
+	err = <-errs
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "no response within 50ms")
+}
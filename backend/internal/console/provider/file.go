@@ -0,0 +1,303 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+const (
+	// defaultWatchInterval is how often a watched directory is checked for new files.
+	defaultWatchInterval = 5 * time.Second
+	// maxLineSize limits the size of a single NDJSON line.
+	maxLineSize = 1 << 20
+)
+
+// Formats of the files of a file provider.
+const (
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+)
+
+// formatsByExtension are the formats of files without a configured format.
+var formatsByExtension = map[string]string{
+	".json":   FormatJSON,
+	".ndjson": FormatNDJSON,
+	".jsonl":  FormatNDJSON,
+	".csv":    FormatCSV,
+}
+
+// fileProvider reads tasks from a file, or from the files of a directory.
+// JSON files hold the tasks as in the responses of HTTP providers, NDJSON
+// files one task per line and CSV files one task per row, named by the header.
+type fileProvider struct {
+	cfg  Config
+	path string
+}
+
+func init() {
+	Register("file", newFileProvider)
+}
+
+func newFileProvider(cfg Config) (Provider, error) {
+	path := cfg.Path
+	if path == "" && cfg.URL != "" {
+		parsed, err := url.Parse(cfg.URL)
+		if err != nil || parsed.Scheme != "file" || parsed.Path == "" {
+			return nil, fmt.Errorf("invalid url %q", cfg.URL)
+		}
+		path = parsed.Path
+	}
+	if path == "" {
+		return nil, errors.New("a path is required")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Watch && !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory, only directories are watched", path)
+	}
+	if !info.IsDir() {
+		if _, err := fileFormat(cfg, path); err != nil {
+			return nil, err
+		}
+	}
+	switch cfg.Format {
+	case "", FormatJSON, FormatNDJSON, FormatCSV:
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected json, ndjson or csv", cfg.Format)
+	}
+	return &fileProvider{cfg: cfg, path: path}, nil
+}
+
+func (p *fileProvider) Name() string {
+	return p.cfg.Name
+}
+
+// Source implements Provider.
+func (p *fileProvider) Source() string {
+	return p.path
+}
+
+// Fetch implements Provider. The files of a directory are read in the order of
+// their names. A watched directory is checked for new or changed files until
+// ctx is done. Files should be moved into it once written, files starting with
+// a dot are skipped.
+func (p *fileProvider) Fetch(ctx context.Context) (<-chan payload.CreateTaskRequest, <-chan error) {
+	return stream(ctx, func(s *sink) error {
+		info, err := os.Stat(p.path)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return p.readFile(s, p.path)
+		}
+
+		interval := p.cfg.Interval
+		if interval <= 0 {
+			interval = defaultWatchInterval
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		read := map[string]time.Time{}
+		for {
+			if err := p.readDir(s, read); err != nil {
+				return err
+			}
+			if !p.cfg.Watch {
+				return nil
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	})
+}
+
+// readDir reads the files of the directory that were not read yet or changed
+// since, as recorded in read.
+func (p *fileProvider) readDir(s *sink, read map[string]time.Time) error {
+	entries, err := os.ReadDir(p.path)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if _, err := fileFormat(p.cfg, entry.Name()); err != nil {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		file := filepath.Join(p.path, name)
+		info, err := os.Stat(file)
+		if err != nil {
+			// The file was moved away since the directory was read
+			continue
+		}
+		if modTime, ok := read[name]; ok && modTime.Equal(info.ModTime()) {
+			continue
+		}
+		read[name] = info.ModTime()
+
+		if err := p.readFile(s, file); err != nil {
+			// A broken file does not stop the others
+			if !s.error(fmt.Errorf("%s: %w", file, err)) {
+				return nil
+			}
+		}
+		if s.ctx.Err() != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (p *fileProvider) readFile(s *sink, file string) error {
+	format, err := fileFormat(p.cfg, file)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Errors of single tasks name the file they are in
+	send := func(index int, raw interface{}, err error) bool {
+		var task payload.CreateTaskRequest
+		if err == nil {
+			task, err = p.cfg.task(raw)
+		}
+		if err != nil {
+			err = fmt.Errorf("%s: %w", file, err)
+		}
+		return s.send(index, task, err)
+	}
+
+	switch format {
+	case FormatNDJSON:
+		return readNDJSON(f, send)
+	case FormatCSV:
+		return readCSV(f, send)
+	default:
+		return readJSON(f, p.cfg, send)
+	}
+}
+
+// fileFormat returns the configured format, or the format of the extension of the file.
+func fileFormat(cfg Config, file string) (string, error) {
+	if cfg.Format != "" {
+		return cfg.Format, nil
+	}
+	format, ok := formatsByExtension[strings.ToLower(filepath.Ext(file))]
+	if !ok {
+		return "", fmt.Errorf("unknown format of %s, expected a .json, .ndjson, .jsonl or .csv file", file)
+	}
+	return format, nil
+}
+
+// sendFunc sends a raw task at an index of a file, or the error reading it.
+// It returns false when the fetch is cancelled.
+type sendFunc func(index int, raw interface{}, err error) bool
+
+func readJSON(r io.Reader, cfg Config, send sendFunc) error {
+	err := decodeTasks(r, cfg.tasks, send)
+	switch {
+	case errors.Is(err, errNoTasks):
+		return fmt.Errorf("no tasks at %q", cfg.Tasks)
+	case errors.Is(err, errNotArray):
+		return fmt.Errorf("tasks at %q are not an array", cfg.Tasks)
+	}
+	return err
+}
+
+func readNDJSON(r io.Reader, send sendFunc) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineSize)
+	for index := 0; scanner.Scan(); {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var raw interface{}
+		err := json.Unmarshal(line, &raw)
+		if err != nil {
+			err = fmt.Errorf("invalid JSON: %w", err)
+		}
+		if !send(index, raw, err) {
+			return nil
+		}
+		index++
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("invalid NDJSON: %w", err)
+	}
+	return nil
+}
+
+// readCSV reads one task per row, as an object with the columns of the header
+// as keys. Empty cells are left out, so they count as missing values.
+func readCSV(r io.Reader, send sendFunc) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("invalid CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	for index := 0; ; index++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			return err
+		}
+
+		raw := map[string]interface{}{}
+		if err == nil {
+			for i, value := range record {
+				if value = strings.TrimSpace(value); value != "" {
+					raw[header[i]] = value
+				}
+			}
+		}
+		if !send(index, raw, err) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, file, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("JSON", func(t *testing.T) {
+		file := filepath.Join(dir, "tasks.json")
+		writeFile(t, file, `{"tasks": [{"id": 1, "zorluk": 3, "sure": 5}, {"id": 2, "value": 1, "estimated_duration": 2}]}`)
+
+		p, err := New(Config{URL: "file://" + file, Tasks: "tasks"})
+		require.NoError(t, err)
+		require.Equal(t, "file://"+file, p.Name())
+		require.Equal(t, file, p.Source())
+		tasks, errs := collect(p)
+		require.Empty(t, errs)
+		require.Len(t, tasks, 2)
+		require.Equal(t, "file://"+file, tasks[0].Provider)
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		file := filepath.Join(dir, "tasks.ndjson")
+		writeFile(t, file, "{\"id\": 1, \"zorluk\": 3, \"sure\": 5}\n\nnot json\n{\"id\": 2, \"zorluk\": 1, \"sure\": 2}\n")
+
+		p, err := New(Config{Name: "drops", Path: file})
+		require.NoError(t, err)
+		tasks, errs := collect(p)
+		require.Len(t, tasks, 2)
+		require.Equal(t, "drops", tasks[1].Provider)
+		require.Len(t, errs, 1)
+		var taskErr *TaskError
+		require.True(t, errors.As(errs[0], &taskErr))
+		require.Equal(t, 1, taskErr.Index)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		file := filepath.Join(dir, "tasks.csv")
+		writeFile(t, file, "\ufeffKey,Title,Estimate (min),Points,Due Date,Labels\n"+
+			"7,Set up CI,90,3,2024-05-01,\"backend, ops\"\n"+
+			"8,Write docs,30,1,,\n"+
+			"9,Broken,1\n")
+
+		p, err := New(Config{Path: file, Fields: &Mapping{
+			ID:         Field{Path: "Key"},
+			Name:       Field{Path: "Title"},
+			Duration:   Field{Path: "['Estimate (min)']", Unit: "minutes"},
+			Difficulty: Field{Path: "Points"},
+			DueDate:    Field{Path: "['Due Date']"},
+			Skills:     Field{Path: "Labels"},
+		}})
+		require.NoError(t, err)
+		tasks, errs := collect(p)
+		require.Len(t, errs, 1)
+		require.Len(t, tasks, 2)
+
+		dueDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, payload.CreateTaskRequest{
+			ExternalID: 7, Name: "Set up CI", Duration: 2, Difficulty: 3, DueDate: &dueDate,
+			Skills: payload.Skills{"backend", "ops"}, Provider: file,
+		}, tasks[0])
+		require.Nil(t, tasks[1].DueDate)
+		require.Empty(t, tasks[1].Skills)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := New(Config{Path: filepath.Join(dir, "missing.json")})
+		require.Error(t, err)
+		writeFile(t, filepath.Join(dir, "tasks.txt"), "")
+		_, err = New(Config{Path: filepath.Join(dir, "tasks.txt")})
+		require.ErrorContains(t, err, "unknown format")
+		_, err = New(Config{Path: filepath.Join(dir, "tasks.json"), Watch: true})
+		require.ErrorContains(t, err, "not a directory")
+		_, err = New(Config{Path: dir, Format: "xml"})
+		require.ErrorContains(t, err, "unknown format")
+	})
+}
+
+func TestFileProviderWatch(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "1.json"), `[{"id": 1, "zorluk": 3, "sure": 5}]`)
+	writeFile(t, filepath.Join(dir, ".2.json"), `[{"id": 2, "zorluk": 3, "sure": 5}]`)
+	writeFile(t, filepath.Join(dir, "readme.txt"), `not tasks`)
+
+	p, err := New(Config{Name: "drops", Path: dir, Watch: true, Interval: 10 * time.Millisecond})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tasks, errs := p.Fetch(ctx)
+
+	next := func() payload.CreateTaskRequest {
+		select {
+		case task := <-tasks:
+			return task
+		case err := <-errs:
+			t.Fatalf("unexpected error: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no task was read")
+		}
+		return payload.CreateTaskRequest{}
+	}
+	require.Equal(t, uint(1), next().ExternalID)
+
+	// A file dropped later is read as well, the hidden one never
+	writeFile(t, filepath.Join(dir, "3.ndjson"), `{"id": 3, "zorluk": 3, "sure": 5}`)
+	require.Equal(t, uint(3), next().ExternalID)
+
+	cancel()
+	for range tasks {
+	}
+	for range errs {
+	}
+}
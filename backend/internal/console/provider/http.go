@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// httpProvider fetches the tasks of a provider as JSON from its URL.
+type httpProvider struct {
+	cfg    Config
+	client *client
+
+	mu sync.Mutex
+	// fetched are the validators of the last fetch, kept in the cache file on Commit
+	fetched *cacheEntry
+}
+
+func init() {
+	Register("http", newHTTPProvider)
+}
+
+func newHTTPProvider(cfg Config) (Provider, error) {
+	parsed, err := url.Parse(cfg.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid url %q", cfg.URL)
+	}
+	c, err := newClient(cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+	return &httpProvider{cfg: cfg, client: c}, nil
+}
+
+func (p *httpProvider) Name() string {
+	return p.cfg.Name
+}
+
+// Source implements Provider, a password in the URL is left out.
+func (p *httpProvider) Source() string {
+	parsed, err := url.Parse(p.cfg.URL)
+	if err != nil {
+		return p.cfg.URL
+	}
+	return parsed.Redacted()
+}
+
+// Fetch implements Provider. Tasks are sent as soon as they are read from the
+// response, which is never held in memory as a whole. With a cache file the
+// request is conditional, a response that did not change since the last
+// committed fetch yields no tasks.
+func (p *httpProvider) Fetch(ctx context.Context) (<-chan payload.CreateTaskRequest, <-chan error) {
+	p.mu.Lock()
+	p.fetched = nil
+	p.mu.Unlock()
+
+	return stream(ctx, func(s *sink) error {
+		var validators cacheEntry
+		if p.cfg.HTTP.CacheFile != "" {
+			var err error
+			if validators, err = readCache(p.cfg.HTTP.CacheFile, p.cfg.URL); err != nil {
+				return err
+			}
+		}
+
+		resp, err := p.client.get(ctx, p.cfg.URL, validators)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode == http.StatusNotModified {
+			return nil
+		}
+
+		err = decodeTasks(resp.Body, p.cfg.tasks, func(index int, raw interface{}, err error) bool {
+			var task payload.CreateTaskRequest
+			if err == nil {
+				task, err = p.cfg.task(raw)
+			}
+			return s.send(index, task, err)
+		})
+		switch {
+		case errors.Is(err, errNoTasks):
+			return fmt.Errorf("no tasks at %q in the response of %s", p.cfg.Tasks, p.cfg.URL)
+		case errors.Is(err, errNotArray):
+			return fmt.Errorf("tasks at %q in the response of %s are not an array", p.cfg.Tasks, p.cfg.URL)
+		case err != nil:
+			return fmt.Errorf("failed to read the response of %s: %w", p.cfg.URL, err)
+		case ctx.Err() != nil:
+			return nil
+		}
+
+		p.mu.Lock()
+		p.fetched = &cacheEntry{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+		p.mu.Unlock()
+		return nil
+	})
+}
+
+// Commit implements Committer. The validators of the last fetch are kept only
+// once its tasks were saved, so a fetch that was interrupted or failed to be
+// saved is read again in full.
+func (p *httpProvider) Commit() error {
+	p.mu.Lock()
+	fetched := p.fetched
+	p.fetched = nil
+	p.mu.Unlock()
+
+	if fetched == nil || p.cfg.HTTP.CacheFile == "" {
+		return nil
+	}
+	return writeCache(p.cfg.HTTP.CacheFile, p.cfg.URL, *fetched)
+}
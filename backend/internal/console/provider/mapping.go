@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/config"
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultHoursPerDay converts durations given in days when no working calendar is configured.
+const defaultHoursPerDay = 9
+
+// Field maps a value of a provider task. It is written either as its path
+// alone or as an object with the path and how to convert the value:
+//
+//	duration: { path: estimate.minutes, unit: minutes }
+type Field struct {
+	// Path of the value. Alternatives separated by "|" are tried in order.
+	Path string `yaml:"path"`
+	// Unit of a duration: minutes, hours or days of the working calendar.
+	Unit string `yaml:"unit"`
+	// Scale multiplies a number after its unit is converted.
+	Scale float64 `yaml:"scale"`
+
+	paths []path
+}
+
+// UnmarshalYAML accepts a path alone as well as the whole field.
+func (f *Field) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		f.Path = value.Value
+		return nil
+	}
+	type field Field
+	return value.Decode((*field)(f))
+}
+
+func (f *Field) compile(name string) error {
+	f.paths = nil
+	if strings.TrimSpace(f.Path) == "" {
+		return nil
+	}
+	for _, alternative := range strings.Split(f.Path, "|") {
+		p, err := parsePath(alternative)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		f.paths = append(f.paths, p)
+	}
+	return nil
+}
+
+func (f *Field) set() bool {
+	return len(f.paths) > 0
+}
+
+// lookup returns the value of the first alternative present in the task.
+func (f *Field) lookup(raw interface{}) (interface{}, bool) {
+	for _, p := range f.paths {
+		if value, ok := p.lookup(raw); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// number returns the value as a number converted to hours and scaled.
+func (f *Field) number(raw interface{}, name string) (float64, bool, error) {
+	value, ok := f.lookup(raw)
+	if !ok {
+		return 0, false, nil
+	}
+
+	var n float64
+	switch v := value.(type) {
+	case float64:
+		n = v
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, true, fmt.Errorf("invalid %s %q: not a number", name, v)
+		}
+		n = parsed
+	default:
+		return 0, true, fmt.Errorf("invalid %s %v: not a number", name, value)
+	}
+
+	switch f.Unit {
+	case "minutes":
+		n /= 60
+	case "days":
+		n *= hoursPerDay()
+	}
+	if f.Scale != 0 {
+		n *= f.Scale
+	}
+	return n, true, nil
+}
+
+// Mapping maps the tasks of a provider to tasks of the planner. ID, duration
+// and difficulty are required. Durations are converted to hours and rounded
+// up, other numbers are rounded to the nearest integer.
+type Mapping struct {
+	ID         Field `yaml:"id"`
+	Name       Field `yaml:"name"`
+	Duration   Field `yaml:"duration"`
+	Difficulty Field `yaml:"difficulty"`
+	Priority   Field `yaml:"priority"`
+	DueDate    Field `yaml:"dueDate"`
+	Skills     Field `yaml:"skills"`
+}
+
+func (m *Mapping) compile() error {
+	fields := []struct {
+		name     string
+		field    *Field
+		required bool
+	}{
+		{"id", &m.ID, true},
+		{"name", &m.Name, false},
+		{"duration", &m.Duration, true},
+		{"difficulty", &m.Difficulty, true},
+		{"priority", &m.Priority, false},
+		{"dueDate", &m.DueDate, false},
+		{"skills", &m.Skills, false},
+	}
+	for _, f := range fields {
+		if err := f.field.compile(f.name); err != nil {
+			return err
+		}
+		if f.required && !f.field.set() {
+			return fmt.Errorf("field %s: a path is required", f.name)
+		}
+		switch f.field.Unit {
+		case "", "hours":
+		case "minutes", "days":
+			if f.field != &m.Duration {
+				return fmt.Errorf("field %s: only durations have units", f.name)
+			}
+		default:
+			return fmt.Errorf("field %s: unknown unit %q, expected minutes, hours or days", f.name, f.field.Unit)
+		}
+	}
+	return nil
+}
+
+// task maps a task of the provider.
+func (m *Mapping) task(raw interface{}, provider string) (payload.CreateTaskRequest, error) {
+	task := payload.CreateTaskRequest{Provider: provider}
+
+	id, ok, err := m.ID.number(raw, "id")
+	if err != nil {
+		return task, err
+	}
+	if !ok {
+		return task, errors.New("task has no id")
+	}
+	if id < 1 || id != math.Trunc(id) {
+		return task, fmt.Errorf("invalid id %v", id)
+	}
+	task.ExternalID = uint(id)
+
+	duration, ok, err := m.Duration.number(raw, "duration")
+	if err != nil {
+		return task, err
+	}
+	if !ok {
+		return task, fmt.Errorf("task %v has no duration", task.ExternalID)
+	}
+	task.Duration = int(math.Ceil(duration))
+
+	difficulty, ok, err := m.Difficulty.number(raw, "difficulty")
+	if err != nil {
+		return task, err
+	}
+	if !ok {
+		return task, fmt.Errorf("task %v has no difficulty", task.ExternalID)
+	}
+	task.Difficulty = int(math.Round(difficulty))
+
+	task.Name = fmt.Sprintf("Task %v", task.ExternalID)
+	if value, ok := m.Name.lookup(raw); ok {
+		task.Name = strings.TrimSpace(fmt.Sprint(value))
+	}
+
+	priority, ok, err := m.Priority.number(raw, "priority")
+	if err != nil {
+		return task, err
+	}
+	if ok {
+		task.Priority = int(math.Round(priority))
+	}
+
+	if value, ok := m.DueDate.lookup(raw); ok {
+		text, ok := value.(string)
+		if !ok {
+			return task, fmt.Errorf("invalid due date %v: not a string", value)
+		}
+		dueDate, err := parseDueDate(text)
+		if err != nil {
+			return task, err
+		}
+		task.DueDate = &dueDate
+	}
+
+	if value, ok := m.Skills.lookup(raw); ok {
+		switch v := value.(type) {
+		case string:
+			// A comma separated list
+			for _, skill := range strings.Split(v, ",") {
+				if skill = strings.TrimSpace(skill); skill != "" {
+					task.Skills = append(task.Skills, skill)
+				}
+			}
+		case []interface{}:
+			for _, item := range v {
+				skill, ok := item.(string)
+				if !ok {
+					return task, fmt.Errorf("invalid skill %v: not a string", item)
+				}
+				task.Skills = append(task.Skills, skill)
+			}
+		default:
+			return task, fmt.Errorf("invalid skills %v: not a list", value)
+		}
+	}
+	return task, nil
+}
+
+// builtinMappings are the formats of the default providers. They are used for
+// providers without a mapping, each recognised by its difficulty field.
+var builtinMappings = func() []Mapping {
+	mappings := []Mapping{
+		{
+			ID:         Field{Path: "id"},
+			Duration:   Field{Path: "sure"},
+			Difficulty: Field{Path: "zorluk"},
+			Priority:   Field{Path: "priority"},
+			DueDate:    Field{Path: "due_date|dueDate"},
+			Skills:     Field{Path: "skills|tags"},
+		},
+		{
+			ID:         Field{Path: "id"},
+			Duration:   Field{Path: "estimated_duration"},
+			Difficulty: Field{Path: "value"},
+			Priority:   Field{Path: "priority"},
+			DueDate:    Field{Path: "due_date|dueDate"},
+			Skills:     Field{Path: "skills|tags"},
+		},
+	}
+	for i := range mappings {
+		if err := mappings[i].compile(); err != nil {
+			panic(err)
+		}
+	}
+	return mappings
+}()
+
+// builtinTask maps a task in one of the formats of the default providers.
+func builtinTask(raw interface{}, provider string) (payload.CreateTaskRequest, error) {
+	for i := range builtinMappings {
+		if _, ok := builtinMappings[i].Difficulty.lookup(raw); ok {
+			return builtinMappings[i].task(raw, provider)
+		}
+	}
+	return payload.CreateTaskRequest{}, errors.New("unknown provider format")
+}
+
+// hoursPerDay is the length of a working day, used for durations given in days.
+func hoursPerDay() float64 {
+	if app := config.GetApp(); app != nil && app.WorkHoursPerDay > 0 {
+		return app.WorkHoursPerDay
+	}
+	return defaultHoursPerDay
+}
+
+// parseDueDate accepts RFC 3339 timestamps as well as plain dates.
+func parseDueDate(value string) (time.Time, error) {
+	if dueDate, err := time.Parse(time.RFC3339, value); err == nil {
+		return dueDate, nil
+	}
+	dueDate, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid due date %q: %w", value, err)
+	}
+	return dueDate, nil
+}
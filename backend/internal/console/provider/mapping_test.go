@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, text string) interface{} {
+	var doc interface{}
+	require.NoError(t, json.Unmarshal([]byte(text), &doc))
+	return doc
+}
+
+func TestParsePath(t *testing.T) {
+	doc := decode(t, `{"data": {"tasks": [{"id": 7, "meta": {"due-date": "2024-05-01"}}]}}`)
+
+	for text, expected := range map[string]interface{}{
+		"$.data.tasks[0].id":               float64(7),
+		"data.tasks[0]['meta'].id":         nil,
+		`data.tasks[0].meta["due-date"]`:   "2024-05-01",
+		"$['data'].tasks[0].meta.due-date": "2024-05-01",
+		"data.tasks[1].id":                 nil,
+		"data.tasks.id":                    nil,
+	} {
+		p, err := parsePath(text)
+		require.NoError(t, err, text)
+		value, ok := p.lookup(doc)
+		require.Equal(t, expected != nil, ok, text)
+		require.Equal(t, expected, value, text)
+	}
+
+	p, err := parsePath("$")
+	require.NoError(t, err)
+	value, ok := p.lookup(doc)
+	require.True(t, ok)
+	require.Equal(t, doc, value)
+
+	for _, text := range []string{"data..tasks", "tasks[x]", "tasks[0", "tasks['']", "tasks[-1]"} {
+		_, err := parsePath(text)
+		require.Error(t, err, text)
+	}
+}
+
+func TestMapping(t *testing.T) {
+	mapping := Mapping{
+		ID:         Field{Path: "key"},
+		Name:       Field{Path: "fields.summary"},
+		Duration:   Field{Path: "fields.estimate", Unit: "minutes"},
+		Difficulty: Field{Path: "fields.points", Scale: 0.1},
+		Priority:   Field{Path: "fields.priority"},
+		DueDate:    Field{Path: "fields.due|fields.dueDate"},
+		Skills:     Field{Path: "fields.labels"},
+	}
+	require.NoError(t, mapping.compile())
+
+	task, err := mapping.task(decode(t, `{"key": "12", "fields": {"summary": " Set up CI ", "estimate": 90, "points": 46, "priority": 2, "dueDate": "2024-05-01", "labels": "backend, ops"}}`), "tracker")
+	require.NoError(t, err)
+	dueDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, payload.CreateTaskRequest{
+		ExternalID: 12,
+		Name:       "Set up CI",
+		Duration:   2,
+		Difficulty: 5,
+		Priority:   2,
+		DueDate:    &dueDate,
+		Skills:     payload.Skills{"backend", "ops"},
+		Provider:   "tracker",
+	}, task)
+
+	_, err = mapping.task(decode(t, `{"key": 12, "fields": {"points": 40}}`), "tracker")
+	require.EqualError(t, err, "task 12 has no duration")
+	_, err = mapping.task(decode(t, `{"key": "x", "fields": {"estimate": 60, "points": 40}}`), "tracker")
+	require.Error(t, err)
+
+	require.Error(t, (&Mapping{ID: Field{Path: "id"}, Duration: Field{Path: "d"}}).compile())
+	require.Error(t, (&Mapping{ID: Field{Path: "id"}, Duration: Field{Path: "d", Unit: "weeks"}, Difficulty: Field{Path: "x"}}).compile())
+	require.Error(t, (&Mapping{ID: Field{Path: "id"}, Duration: Field{Path: "d"}, Difficulty: Field{Path: "x", Unit: "days"}}).compile())
+}
+
+func TestBuiltinTask(t *testing.T) {
+	task, err := builtinTask(decode(t, `{"id": 1, "zorluk": 3, "sure": 5, "tags": ["backend"]}`), "one")
+	require.NoError(t, err)
+	require.Equal(t, payload.CreateTaskRequest{ExternalID: 1, Name: "Task 1", Duration: 5, Difficulty: 3, Skills: payload.Skills{"backend"}, Provider: "one"}, task)
+
+	task, err = builtinTask(decode(t, `{"id": 2, "value": 4, "estimated_duration": 6, "priority": 1}`), "two")
+	require.NoError(t, err)
+	require.Equal(t, payload.CreateTaskRequest{ExternalID: 2, Name: "Task 2", Duration: 6, Difficulty: 4, Priority: 1, Provider: "two"}, task)
+
+	_, err = builtinTask(decode(t, `{"id": 3, "effort": 4}`), "three")
+	require.EqualError(t, err, "unknown provider format")
+}
+
+func TestLoadConfigs(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "providers.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+providers:
+  - name: tracker
+    url: https://tracker.example.com/api/tasks
+    tasks: $.data.items
+    fields:
+      id: key
+      duration: { path: estimate, unit: days }
+      difficulty: points
+  - url: https://raw.githubusercontent.com/WEG-Technology/mock/refs/heads/main/mock-one
+`), 0o600))
+
+	providers, err := LoadConfigs(file)
+	require.NoError(t, err)
+	require.Len(t, providers, 2)
+	require.Equal(t, "tracker", providers[0].Name)
+	require.Equal(t, "days", providers[0].Fields.Duration.Unit)
+	require.Equal(t, DefaultURLs[0], providers[1].Name)
+	require.Nil(t, providers[1].Fields)
+
+	task, err := providers[0].task(decode(t, `{"key": 5, "estimate": 0.5, "points": 3}`))
+	require.NoError(t, err)
+	require.Equal(t, 5, task.Duration)
+	require.Equal(t, "tracker", task.Provider)
+
+	// JSON files are read as well
+	require.NoError(t, os.WriteFile(file, []byte(`{"providers": [{"url": "https://example.com", "fields": {"id": "id", "duration": "d", "difficulty": "x"}}]}`), 0o600))
+	providers, err = LoadConfigs(file)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com", providers[0].Name)
+
+	for _, content := range []string{
+		``,
+		`providers: [{type: http}]`,
+		`providers: [{url: "https://example.com", mapping: {}}]`,
+		`providers: [{url: "https://example.com"}, {url: "https://example.com"}]`,
+		`providers: [{url: "https://example.com", fields: {id: id, duration: d}}]`,
+	} {
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+		_, err = LoadConfigs(file)
+		require.Error(t, err, content)
+	}
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// pathStep is a key of an object or, when key is empty, an index of an array.
+type pathStep struct {
+	key   string
+	index int
+}
+
+// path selects a value of a decoded JSON document. Paths are written like
+// JSONPath, e.g. "$.data.tasks", "attributes.estimate", "tags[0]" or
+// "meta['due-date']". The leading "$" is optional and "$" alone selects the
+// whole document.
+type path []pathStep
+
+func parsePath(text string) (path, error) {
+	rest := strings.TrimSpace(text)
+	rest = strings.TrimPrefix(rest, "$")
+
+	var p path
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q: empty key", text)
+			}
+			p = append(p, pathStep{key: rest[:end]})
+			rest = rest[end:]
+
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: missing ]", text)
+			}
+			inner := rest[1:end]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				if len(inner) == 2 {
+					return nil, fmt.Errorf("invalid path %q: empty key", text)
+				}
+				p = append(p, pathStep{key: inner[1 : len(inner)-1]})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid path %q: invalid index %q", text, inner)
+				}
+				p = append(p, pathStep{index: index})
+			}
+			rest = rest[end+1:]
+
+		case len(p) == 0:
+			// The first key may be given without a leading dot
+			rest = "." + rest
+
+		default:
+			return nil, fmt.Errorf("invalid path %q: unexpected %q", text, rest[0])
+		}
+	}
+	return p, nil
+}
+
+// lookup returns the value at the path, and false when there is none.
+func (p path) lookup(doc interface{}) (interface{}, bool) {
+	value := doc
+	for _, step := range p {
+		if step.key != "" {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			if value, ok = object[step.key]; !ok {
+				return nil, false
+			}
+			continue
+		}
+
+		array, ok := value.([]interface{})
+		if !ok || step.index >= len(array) {
+			return nil, false
+		}
+		value = array[step.index]
+	}
+	return value, value != nil
+}
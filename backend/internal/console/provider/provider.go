@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/mehmetali10/task-planner/internal/console/worker"
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/pkg/log"
+)
+
+// Provider is a source of tasks.
+type Provider interface {
+	// Name identifies the tasks of the provider.
+	Name() string
+	// Source is the URL or path the tasks are read from, recorded in the
+	// history of the tasks.
+	Source() string
+	// Fetch streams the tasks of the provider. Both channels are closed when
+	// the fetch ends. A task that cannot be read is reported on the error
+	// channel without ending the fetch.
+	Fetch(ctx context.Context) (<-chan payload.CreateTaskRequest, <-chan error)
+}
+
+// Factory creates a provider of a type from its configuration.
+type Factory func(cfg Config) (Provider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a type of providers available to providers files. It
+// panics when the type is registered twice.
+func Register(typeName string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[typeName]; ok {
+		panic(fmt.Sprintf("provider type %q is registered twice", typeName))
+	}
+	registry[typeName] = factory
+}
+
+// Types returns the registered types of providers.
+func Types() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]string, 0, len(registry))
+	for typeName := range registry {
+		types = append(types, typeName)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// New creates the provider configured by cfg.
+func New(cfg Config) (Provider, error) {
+	if err := cfg.compile(); err != nil {
+		return nil, fmt.Errorf("provider %s: %w", cfg.Name, err)
+	}
+
+	registryMu.RLock()
+	factory, ok := registry[cfg.Type]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("provider %s: unknown type %q, expected one of %v", cfg.Name, cfg.Type, Types())
+	}
+
+	p, err := factory(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("provider %s: %w", cfg.Name, err)
+	}
+	return p, nil
+}
+
+// TaskError reports a task of a provider that could not be read, the fetch
+// goes on with the next one.
+type TaskError struct {
+	// Index of the task in the response of the provider.
+	Index int
+	Err   error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %d: %v", e.Index, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// sink sends the results of a fetch to the channels of a Provider.
+type sink struct {
+	ctx   context.Context
+	tasks chan payload.CreateTaskRequest
+	errs  chan error
+}
+
+// stream runs fetch in the background, returning the channels of its results.
+// The error fetch returns ends the stream.
+func stream(ctx context.Context, fetch func(s *sink) error) (<-chan payload.CreateTaskRequest, <-chan error) {
+	s := &sink{ctx: ctx, tasks: make(chan payload.CreateTaskRequest), errs: make(chan error)}
+	go func() {
+		defer close(s.errs)
+		defer close(s.tasks)
+		if err := fetch(s); err != nil {
+			s.error(err)
+		}
+	}()
+	return s.tasks, s.errs
+}
+
+// send sends the task at index, or its error when it could not be mapped. It
+// returns false when the fetch is cancelled.
+func (s *sink) send(index int, task payload.CreateTaskRequest, err error) bool {
+	if err != nil {
+		return s.error(&TaskError{Index: index, Err: err})
+	}
+	select {
+	case s.tasks <- task:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+func (s *sink) error(err error) bool {
+	select {
+	case s.errs <- err:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+// Committer is implemented by providers remembering what they fetched, e.g.
+// the validators of conditional requests, so later fetches skip it.
+type Committer interface {
+	// Commit remembers the last fetch. It is only called once every task of
+	// the fetch was read and saved.
+	Commit() error
+}
+
+// Process fetches the tasks of a provider and submits them to the worker pool.
+// Tasks that cannot be read are logged, the error ending the fetch is returned.
+func Process(ctx context.Context, p Provider, logger log.Logger, wp *worker.WorkerPool) error {
+	tasks, errs := p.Fetch(ctx)
+	receipt := &worker.Receipt{}
+	complete := true
+	var fetchErr error
+	for tasks != nil || errs != nil {
+		select {
+		case task, ok := <-tasks:
+			if !ok {
+				tasks = nil
+				continue
+			}
+			wp.SubmitFrom(p.Source(), task, receipt)
+
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			var taskErr *TaskError
+			if errors.As(err, &taskErr) {
+				logger.Error("Error mapping task of provider %s: %v", p.Name(), err)
+				complete = false
+				continue
+			}
+			// Providers reading many files go on after an error, only the last one is returned
+			if fetchErr != nil {
+				logger.Error("Error processing tasks from provider %s: %v", p.Name(), fetchErr)
+			}
+			fetchErr = err
+		}
+	}
+
+	committer, ok := p.(Committer)
+	if !ok || fetchErr != nil || !complete {
+		return fetchErr
+	}
+	if err := receipt.Wait(ctx); err != nil {
+		// The fetch is read again in full next time
+		logger.Error("Not every task of provider %s was saved: %v", p.Name(), err)
+		return nil
+	}
+	return committer.Commit()
+}
@@ -2,27 +2,45 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/mehmetali10/task-planner/internal/pkg/payload"
 	"github.com/mehmetali10/task-planner/internal/pkg/repository"
 	"github.com/mehmetali10/task-planner/pkg/log"
+	"github.com/mehmetali10/task-planner/pkg/validate"
 )
 
+const (
+	// batchSize is the number of tasks a worker creates at once
+	batchSize = 100
+	// flushInterval is how long a worker waits for a batch to fill up
+	flushInterval = 500 * time.Millisecond
+)
+
+// ErrStopped is reported for tasks submitted while the WorkerPool stops.
+var ErrStopped = errors.New("worker pool stopped")
+
 // WorkerPool management
 type WorkerPool struct {
-	taskQueue chan payload.CreateTaskRequest
+	taskQueue chan job
 	workerNum int
 	repo      repository.Repository
-	wg        sync.WaitGroup // WaitGroup to track workers
+	wg        sync.WaitGroup  // WaitGroup to track workers
+	stopOnce  sync.Once       // Stop may be called on a signal and once all providers are done
+	stopping  chan struct{}   // Closed by Stop, tasks are no longer queued
+	submitMu  sync.RWMutex    // Held by Submit while queueing, so the queue is closed after it
+	cancelled <-chan struct{} // Done of the context of the workers, they no longer take tasks then
 	logger    log.Logger
 }
 
 // NewWorkerPool creates a new Worker Pool
 func NewWorkerPool(workerNum int, repo repository.Repository) *WorkerPool {
 	return &WorkerPool{
-		taskQueue: make(chan payload.CreateTaskRequest, 100), // 100 buffer size
+		taskQueue: make(chan job, 100), // 100 buffer size
+		stopping:  make(chan struct{}),
 		workerNum: workerNum,
 		repo:      repo,
 		logger:    log.NewLogger("worker-pool", "error"),
@@ -31,32 +49,42 @@ func NewWorkerPool(workerNum int, repo repository.Repository) *WorkerPool {
 
 // Start workers
 func (wp *WorkerPool) Start(ctx context.Context) {
+	wp.cancelled = ctx.Done()
 	for i := 0; i < wp.workerNum; i++ {
 		wp.wg.Add(1) // Add a worker
 		go wp.worker(ctx, i)
 	}
 }
 
-// Worker function
+// Worker function. Tasks are created in batches of up to batchSize, a batch
+// that is not full is created after flushInterval.
 func (wp *WorkerPool) worker(ctx context.Context, workerID int) {
 	defer wp.wg.Done() // Remove from WaitGroup when worker finishes
 
+	batch := make([]job, 0, batchSize)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
 	wp.logger.Error("Worker %d started...", workerID)
 	for {
 		select {
-		case task, ok := <-wp.taskQueue:
+		case j, ok := <-wp.taskQueue:
 			if !ok {
+				wp.flush(ctx, workerID, batch)
 				wp.logger.Error("Worker %d: Task queue closed, exiting...", workerID)
 				return
 			}
 
-			resp, err := wp.repo.CreateTask(ctx, task)
-			if err != nil {
-				wp.logger.Error("Worker %d: Error creating task: %v", workerID, err)
-			} else {
-				wp.logger.Debug("Worker %d: Task created successfully: %+v", workerID, resp)
+			batch = append(batch, j)
+			if len(batch) == batchSize {
+				wp.flush(ctx, workerID, batch)
+				batch = batch[:0]
 			}
 
+		case <-ticker.C:
+			wp.flush(ctx, workerID, batch)
+			batch = batch[:0]
+
 		case <-ctx.Done():
 			wp.logger.Info("Worker %d stopping...", workerID)
 			return
@@ -64,17 +92,180 @@ func (wp *WorkerPool) worker(ctx context.Context, workerID int) {
 	}
 }
 
+// flush saves a batch of tasks in one go per provider, so the history of the
+// tasks records the URL or path they came from, or the name of the provider
+// when it is not known
+func (wp *WorkerPool) flush(ctx context.Context, workerID int, batch []job) {
+	if len(batch) == 0 {
+		return
+	}
+
+	var actors []string
+	byActor := map[string][]job{}
+	for _, j := range batch {
+		actor := j.source
+		if actor == "" {
+			actor = j.task.Provider
+		}
+		if _, ok := byActor[actor]; !ok {
+			actors = append(actors, actor)
+		}
+		byActor[actor] = append(byActor[actor], j)
+	}
+
+	for _, actor := range actors {
+		origin := repository.Origin{Source: repository.SourceConsole, Actor: actor}
+		wp.save(repository.WithOrigin(ctx, origin), workerID, byActor[actor])
+	}
+}
+
+func (wp *WorkerPool) save(ctx context.Context, workerID int, jobs []job) {
+	tasks := make([]payload.CreateTaskRequest, len(jobs))
+	for i, j := range jobs {
+		tasks[i] = j.task
+	}
+	results, err := wp.repo.UpsertTasks(ctx, tasks)
+	if err != nil {
+		wp.logger.Error("Worker %d: Error saving %d tasks: %v", workerID, len(tasks), err)
+		for _, j := range jobs {
+			j.receipt.done(err)
+		}
+		return
+	}
+
+	errs := make([]error, len(jobs))
+	for _, result := range results {
+		switch result.Status {
+		case payload.ImportDuplicate:
+			wp.logger.Warn("Worker %d: Task given twice externalId=%v, provider=%v", workerID, result.ExternalID, result.Provider)
+		case payload.ImportInvalid, payload.ImportFailed:
+			wp.logger.Error("Worker %d: Task %s externalId=%v, provider=%v: %s", workerID, result.Status, result.ExternalID, result.Provider, result.Error)
+			if result.Index >= 0 && result.Index < len(errs) {
+				errs[result.Index] = fmt.Errorf("task %v %s: %s", result.ExternalID, result.Status, result.Error)
+			}
+		default:
+			wp.logger.Debug("Worker %d: Task %s: %+v", workerID, result.Status, result)
+		}
+	}
+	for i, j := range jobs {
+		j.receipt.done(errs[i])
+	}
+}
+
 // SubmitTask submits a task to the WorkerPool
 func (wp *WorkerPool) SubmitTask(task payload.CreateTaskRequest) {
-	wp.taskQueue <- task
+	wp.Submit(task, nil)
 }
 
-// Stop stops the WorkerPool
+// Submit submits a task to the WorkerPool, the receipt learns whether it was
+// saved. The receipt may be nil. Invalid tasks are not queued, so they do not
+// fail the batch they would be saved with. A task submitted once Stop is
+// called or the context of the workers is done, or waiting for room in the
+// queue then, fails with ErrStopped.
+func (wp *WorkerPool) Submit(task payload.CreateTaskRequest, receipt *Receipt) {
+	wp.SubmitFrom("", task, receipt)
+}
+
+// SubmitFrom submits a task read from source, the URL or path of its provider,
+// like Submit. The history of the task records the source, or the name of the
+// provider when source is empty.
+func (wp *WorkerPool) SubmitFrom(source string, task payload.CreateTaskRequest, receipt *Receipt) {
+	receipt.add()
+	if err := validate.Request(task); err != nil {
+		wp.logger.Error("Task %s externalId=%v, provider=%v: %v", payload.ImportInvalid, task.ExternalID, task.Provider, err)
+		receipt.done(fmt.Errorf("task %v %s: %w", task.ExternalID, payload.ImportInvalid, err))
+		return
+	}
+
+	wp.submitMu.RLock()
+	defer wp.submitMu.RUnlock()
+	stopped := fmt.Errorf("task %v: %w", task.ExternalID, ErrStopped)
+	// The queue may be closed once stopping is, so it is not sent to then
+	select {
+	case <-wp.stopping:
+		receipt.done(stopped)
+		return
+	default:
+	}
+	select {
+	case wp.taskQueue <- job{task: task, source: source, receipt: receipt}:
+	case <-wp.stopping:
+		receipt.done(stopped)
+	case <-wp.cancelled:
+		receipt.done(stopped)
+	}
+}
+
+// Stop stops the WorkerPool, later calls wait for the first one to finish
 func (wp *WorkerPool) Stop() {
-	wp.logger.Info("Stopping worker pool... Waiting for remaining tasks.")
+	wp.stopOnce.Do(func() {
+		wp.logger.Info("Stopping worker pool... Waiting for remaining tasks.")
+		close(wp.stopping) // Submit no longer queues tasks, nor waits for room
+
+		time.Sleep(time.Second * 2) // Wait for 2 seconds for remaining tasks to finish
 
-	time.Sleep(time.Second * 2) // Wait for 2 seconds for remaining tasks to finish
+		wp.submitMu.Lock()  // Wait for the tasks being submitted
+		close(wp.taskQueue) // Close the queue, workers will start shutting down
+		wp.submitMu.Unlock()
+		wp.wg.Wait() // Wait for all workers to finish
+	})
+}
+
+// job is a task in the queue, with the URL or path it was read from and the
+// receipt of its submission.
+type job struct {
+	task    payload.CreateTaskRequest
+	source  string
+	receipt *Receipt
+}
 
-	close(wp.taskQueue) // Close the queue, workers will start shutting down
-	wp.wg.Wait()        // Wait for all workers to finish
+// Receipt tells whether the tasks submitted with it were saved, e.g. all
+// tasks of one fetch of a provider. The zero value is ready to use.
+type Receipt struct {
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	err  error
+	once sync.Once
+	all  chan struct{}
+}
+
+func (r *Receipt) add() {
+	if r != nil {
+		r.wg.Add(1)
+	}
+}
+
+func (r *Receipt) done(err error) {
+	if r == nil {
+		return
+	}
+	if err != nil {
+		r.mu.Lock()
+		if r.err == nil {
+			r.err = err
+		}
+		r.mu.Unlock()
+	}
+	r.wg.Done()
+}
+
+// Wait waits until every task submitted with the receipt was saved or failed
+// to, and returns the first error. Tasks still queued when ctx is done are
+// never saved, Wait returns the error of ctx then.
+func (r *Receipt) Wait(ctx context.Context) error {
+	r.once.Do(func() {
+		r.all = make(chan struct{})
+		go func() {
+			r.wg.Wait()
+			close(r.all)
+		}()
+	})
+	select {
+	case <-r.all:
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -0,0 +1,133 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+// savingRepo records the tasks it saves and the actor saving them, a task
+// without a name fails the batch like a database constraint would.
+type savingRepo struct {
+	repository.Repository
+	mu     sync.Mutex
+	saved  []payload.CreateTaskRequest
+	actors []string
+}
+
+func (r *savingRepo) UpsertTasks(ctx context.Context, reqs []payload.CreateTaskRequest) ([]payload.TaskImportResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	results := make([]payload.TaskImportResult, len(reqs))
+	for i, req := range reqs {
+		if req.Name == "" {
+			return nil, errors.New("null value in column \"Name\"")
+		}
+		r.saved = append(r.saved, req)
+		r.actors = append(r.actors, repository.OriginFrom(ctx).Actor)
+		results[i] = payload.TaskImportResult{Index: i, ExternalID: req.ExternalID, Provider: req.Provider, Status: payload.ImportCreated}
+	}
+	return results, nil
+}
+
+func TestWorkerPoolInvalidTasks(t *testing.T) {
+	repo := &savingRepo{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wp := NewWorkerPool(1, repo)
+	wp.Start(ctx)
+
+	// An invalid task fails on its own, the tasks submitted with it are saved
+	receipt := &Receipt{}
+	wp.Submit(payload.CreateTaskRequest{ExternalID: 1, Name: "Valid", Duration: 2, Difficulty: 3, Provider: "tracker"}, receipt)
+	wp.Submit(payload.CreateTaskRequest{ExternalID: 2, Duration: 2, Difficulty: 3, Provider: "tracker"}, receipt)
+	wp.Submit(payload.CreateTaskRequest{ExternalID: 3, Name: "Also valid", Duration: 1, Difficulty: 1, Provider: "tracker"}, receipt)
+
+	err := receipt.Wait(ctx)
+	require.ErrorContains(t, err, "task 2 invalid")
+	require.Len(t, repo.saved, 2)
+	require.Equal(t, uint(1), repo.saved[0].ExternalID)
+	require.Equal(t, uint(3), repo.saved[1].ExternalID)
+
+	receipt = &Receipt{}
+	wp.Submit(payload.CreateTaskRequest{ExternalID: 4, Name: "Valid", Duration: 2, Difficulty: 3, Provider: "tracker"}, receipt)
+	require.NoError(t, receipt.Wait(ctx))
+}
+
+func TestWorkerPoolActor(t *testing.T) {
+	repo := &savingRepo{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	wp := NewWorkerPool(1, repo)
+	wp.Start(ctx)
+
+	// The history records where the tasks were read from, the name of the
+	// provider when that is not known
+	receipt := &Receipt{}
+	wp.SubmitFrom("https://example.com/tasks", payload.CreateTaskRequest{ExternalID: 1, Name: "Task", Duration: 2, Difficulty: 3, Provider: "tracker"}, receipt)
+	wp.SubmitFrom("/data/tasks.csv", payload.CreateTaskRequest{ExternalID: 2, Name: "Task", Duration: 2, Difficulty: 3, Provider: "tracker"}, receipt)
+	wp.Submit(payload.CreateTaskRequest{ExternalID: 3, Name: "Task", Duration: 2, Difficulty: 3, Provider: "tracker"}, receipt)
+	require.NoError(t, receipt.Wait(ctx))
+
+	actors := map[uint]string{}
+	for i, task := range repo.saved {
+		actors[task.ExternalID] = repo.actors[i]
+	}
+	require.Equal(t, map[uint]string{1: "https://example.com/tasks", 2: "/data/tasks.csv", 3: "tracker"}, actors)
+}
+
+func TestWorkerPoolStop(t *testing.T) {
+	valid := func(externalID uint) payload.CreateTaskRequest {
+		return payload.CreateTaskRequest{ExternalID: externalID, Name: "Valid", Duration: 2, Difficulty: 3, Provider: "tracker"}
+	}
+	// fill submits tasks until the queue is full, there are no workers taking them
+	fill := func(wp *WorkerPool) {
+		for i := 1; i <= cap(wp.taskQueue); i++ {
+			wp.Submit(valid(uint(i)), nil)
+		}
+	}
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		wp := NewWorkerPool(0, &savingRepo{})
+		wp.Start(ctx)
+		fill(wp)
+
+		// A task waiting for room fails once the workers are cancelled
+		receipt := &Receipt{}
+		submitted := make(chan struct{})
+		go func() {
+			defer close(submitted)
+			wp.Submit(valid(1000), receipt)
+		}()
+		cancel()
+		<-submitted
+		require.ErrorIs(t, receipt.Wait(context.Background()), ErrStopped)
+	})
+
+	t.Run("Stopped", func(t *testing.T) {
+		wp := NewWorkerPool(0, &savingRepo{})
+		wp.Start(context.Background())
+		fill(wp)
+
+		// Stop neither waits for nor panics on a task waiting for room
+		receipt := &Receipt{}
+		submitted := make(chan struct{})
+		go func() {
+			defer close(submitted)
+			wp.Submit(valid(1000), receipt)
+		}()
+		wp.Stop()
+		<-submitted
+		require.ErrorIs(t, receipt.Wait(context.Background()), ErrStopped)
+
+		receipt = &Receipt{}
+		wp.Submit(valid(1001), receipt)
+		require.ErrorIs(t, receipt.Wait(context.Background()), ErrStopped)
+	})
+}
@@ -25,6 +25,21 @@ type app struct {
 	DBUser     string
 	DBPassword string
 	DBName     string
+
+	// Working calendar used by the scheduler
+	WorkHoursPerDay  float64
+	WorkDayStartHour float64
+	WorkDays         []string
+	PublicHolidays   []string
+	PlanStartDate    string
+
+	// Skill matching used by the scheduler
+	SkillMatch   string
+	SkillPenalty float64
+
+	// Task splitting and the number of weeks the scheduler may plan ahead
+	TaskSplit    string
+	MaxPlanWeeks int
 }
 
 var appConf *app
@@ -49,7 +64,7 @@ func LoadConfig() error {
 
 	// Handle CORS settings, with default values if not set
 	appConf.HTTPAllowedOrigins = parseCSV(os.Getenv("HTTP_ALLOWED_ORIGINS"), "*")
-	appConf.HTTPAllowedMethods = parseCSV(os.Getenv("HTTP_ALLOWED_METHODS"), "GET,POST,PUT,DELETE,OPTIONS")
+	appConf.HTTPAllowedMethods = parseCSV(os.Getenv("HTTP_ALLOWED_METHODS"), "GET,POST,PUT,PATCH,DELETE,OPTIONS")
 	appConf.HTTPAllowedHeaders = parseCSV(os.Getenv("HTTP_ALLOWED_HEADERS"), "*")
 
 	// Load database configuration
@@ -70,6 +85,63 @@ func LoadConfig() error {
 		appConf.DBPort = dbPort
 	}
 
+	// Load the working calendar, defaulting to 9 hour days from Monday to Friday
+	workHoursStr := os.Getenv("WORK_HOURS_PER_DAY")
+	if workHoursStr == "" {
+		appConf.WorkHoursPerDay = 9
+	} else {
+		workHours, err := strconv.ParseFloat(workHoursStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid WORK_HOURS_PER_DAY value: %v", err)
+		}
+		appConf.WorkHoursPerDay = workHours
+	}
+	dayStartStr := os.Getenv("WORK_DAY_START_HOUR")
+	if dayStartStr == "" {
+		appConf.WorkDayStartHour = 9
+	} else {
+		dayStart, err := strconv.ParseFloat(dayStartStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid WORK_DAY_START_HOUR value: %v", err)
+		}
+		appConf.WorkDayStartHour = dayStart
+	}
+	appConf.WorkDays = parseCSV(os.Getenv("WORK_DAYS"), "Mon,Tue,Wed,Thu,Fri")
+	appConf.PublicHolidays = parseCSV(os.Getenv("PUBLIC_HOLIDAYS"), "")
+	appConf.PlanStartDate = os.Getenv("PLAN_START_DATE")
+
+	// Load the skill matching, defaulting to hard matching and a penalty of 1.5 per missing skill in soft mode
+	appConf.SkillMatch = os.Getenv("SKILL_MATCH")
+	if appConf.SkillMatch == "" {
+		appConf.SkillMatch = "hard"
+	}
+	skillPenaltyStr := os.Getenv("SKILL_PENALTY")
+	if skillPenaltyStr == "" {
+		appConf.SkillPenalty = 1.5
+	} else {
+		skillPenalty, err := strconv.ParseFloat(skillPenaltyStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid SKILL_PENALTY value: %v", err)
+		}
+		appConf.SkillPenalty = skillPenalty
+	}
+
+	// Load the task splitting, defaulting to splitting over weeks and a horizon of ten years
+	appConf.TaskSplit = os.Getenv("TASK_SPLIT")
+	if appConf.TaskSplit == "" {
+		appConf.TaskSplit = "weeks"
+	}
+	maxPlanWeeksStr := os.Getenv("MAX_PLAN_WEEKS")
+	if maxPlanWeeksStr == "" {
+		appConf.MaxPlanWeeks = 520
+	} else {
+		maxPlanWeeks, err := strconv.Atoi(maxPlanWeeksStr)
+		if err != nil || maxPlanWeeks <= 0 {
+			return fmt.Errorf("invalid MAX_PLAN_WEEKS value: %q", maxPlanWeeksStr)
+		}
+		appConf.MaxPlanWeeks = maxPlanWeeks
+	}
+
 	return nil
 }
 
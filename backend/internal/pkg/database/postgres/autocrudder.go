@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/mehmetali10/task-planner/pkg/automapper"
 
@@ -106,3 +107,143 @@ func Update[Dest any, Source any](ctx context.Context, rule any, req any) (Dest,
 
 	return resp, nil
 }
+
+// UpdateColumns sets the given columns of the records matching the provided rule.
+// Unlike Update it also writes zero values, such as an empty list.
+// It returns the number of records that were updated.
+func UpdateColumns[Source any](ctx context.Context, rule any, columns map[string]interface{}) (int64, error) {
+	ConnectToDB()
+	defer CloseDB()
+
+	var existingItem Source
+
+	db := DB.WithContext(ctx).Model(&existingItem).Where(rule).Updates(columns)
+
+	if db.Error != nil {
+		return 0, db.Error
+	}
+
+	return db.RowsAffected, nil
+}
+
+// Delete marks the database records matching the provided rule as deleted.
+// It returns the number of records that were marked.
+func Delete[Source any](ctx context.Context, rule any) (int64, error) {
+	ConnectToDB()
+	defer CloseDB()
+
+	var existingItem Source
+
+	db := DB.WithContext(ctx).Model(&existingItem).Where(map[string]interface{}{"IsDeleted": false}).Where(rule).Update("IsDeleted", true)
+
+	if db.Error != nil {
+		return 0, db.Error
+	}
+
+	return db.RowsAffected, nil
+}
+
+// Condition is a raw SQL condition with its arguments, e.g. `"Duration" >= ?`.
+type Condition struct {
+	Query string
+	Args  []any
+}
+
+// PageQuery describes a page of records to read with ReadPage.
+type PageQuery struct {
+	// Where holds the filters, they are counted in the total.
+	Where []Condition
+	// After positions the page, it is not counted in the total.
+	After *Condition
+	// Order holds the ORDER BY expressions, applied in order.
+	Order  []string
+	Limit  int
+	Offset int
+}
+
+// ReadPage fetches a page of database records matching the query together with
+// the number of records matching its filters, regardless of the page.
+// Unlike Read the limit is used as given, it has to be positive.
+func ReadPage[Dest any, Source any](ctx context.Context, query PageQuery) (Dest, int64, error) {
+	ConnectToDB()
+	defer CloseDB()
+
+	var resp Dest
+	var total int64
+	var existingItem Source
+
+	db := DB.WithContext(ctx).Model(&existingItem)
+	for _, condition := range query.Where {
+		db = db.Where(condition.Query, condition.Args...)
+	}
+
+	if err := db.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return resp, 0, err
+	}
+
+	if query.After != nil {
+		db = db.Where(query.After.Query, query.After.Args...)
+	}
+	for _, order := range query.Order {
+		db = db.Order(order)
+	}
+
+	if err := db.Limit(query.Limit).Offset(query.Offset).Find(&resp).Error; err != nil {
+		return resp, 0, err
+	}
+
+	return resp, total, nil
+}
+
+// ReadRaw runs a raw SQL query and scans its rows into the destination.
+// It is meant for queries the other helpers cannot express, such as text search.
+func ReadRaw[Dest any](ctx context.Context, query string, args ...any) (Dest, error) {
+	ConnectToDB()
+	defer CloseDB()
+
+	var resp Dest
+
+	if err := DB.WithContext(ctx).Raw(query, args...).Scan(&resp).Error; err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Transaction runs fn in a database transaction. The transaction is committed
+// when fn returns nil and rolled back otherwise.
+func Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	ConnectToDB()
+	defer CloseDB()
+
+	return DB.WithContext(ctx).Transaction(fn)
+}
+
+// Iterate reads the rows of a query in batches of batchSize through a
+// server-side cursor, so they are never all held in memory. fn is called with
+// every batch, an error it returns stops the iteration. All batches are read
+// from the same snapshot of the database.
+func Iterate[Dest any](ctx context.Context, batchSize int, fn func(batch []Dest) error, query string, args ...any) error {
+	return Transaction(ctx, func(tx *gorm.DB) error {
+		if err := tx.Exec("DECLARE iterate_cursor NO SCROLL CURSOR FOR "+query, args...).Error; err != nil {
+			return err
+		}
+
+		fetch := fmt.Sprintf("FETCH FORWARD %d FROM iterate_cursor", batchSize)
+		for {
+			var batch []Dest
+			if err := tx.Raw(fetch).Scan(&batch).Error; err != nil {
+				return err
+			}
+			if len(batch) == 0 {
+				return nil
+			}
+			if err := fn(batch); err != nil {
+				return err
+			}
+			if len(batch) < batchSize {
+				return nil
+			}
+		}
+	})
+}
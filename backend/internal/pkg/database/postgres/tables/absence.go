@@ -0,0 +1,35 @@
+package tables
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Absence struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	DeveloperID uint       `gorm:"not null;index" json:"developer_id"`
+	StartDate   time.Time  `gorm:"type:date;not null" json:"start_date"`
+	EndDate     time.Time  `gorm:"type:date;not null" json:"end_date"`
+	Reason      string     `json:"reason"`
+	IsDeleted   bool       `gorm:"not null;default:false" json:"is_deleted"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+func (Absence) TableName() string {
+	return "tb_absences"
+}
+
+func (a *Absence) BeforeCreate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	a.CreatedAt = &now
+	a.UpdatedAt = &now
+	return
+}
+
+func (a *Absence) BeforeUpdate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	a.UpdatedAt = &now
+	return
+}
@@ -3,19 +3,28 @@ package tables
 import (
 	"time"
 
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
 	"gorm.io/gorm"
 )
 
 type Developer struct {
-	ID        uint      `gorm:"primaryKey" json:"id"`
-	FirstName string    `gorm:"not null" json:"first_name"`
-	Capacity  int       `gorm:"not null" json:"capacity"`
-	LastName  string    `json:"last_name"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID              uint           `gorm:"primaryKey" json:"id"`
+	FirstName       string         `gorm:"not null" json:"first_name"`
+	Capacity        int            `gorm:"not null" json:"capacity"`
+	LastName        string         `json:"last_name"`
+	Email           string         `json:"email"`
+	WeeklyHours     float64        `json:"weekly_hours"`
+	PartTimePercent int            `gorm:"not null;default:100" json:"part_time_percent"`
+	Skills          payload.Skills `gorm:"type:text" json:"skills"`
+	IsDeleted       bool           `gorm:"not null;default:false" json:"is_deleted"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
 }
 
+// DeveloperEmailIndex keeps the emails of the developers that are not deleted
+// unique regardless of case, maintained by the migrations next to the table.
+const DeveloperEmailIndex = "idx_tb_developers_email"
+
 func (Developer) TableName() string {
 	return "tb_developers"
 }
@@ -0,0 +1,42 @@
+package tables
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Plan struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name       string     `gorm:"not null;uniqueIndex:idx_tb_plans_name_version" json:"name"`
+	Version    int        `gorm:"not null;uniqueIndex:idx_tb_plans_name_version" json:"version"`
+	Strategy   string     `gorm:"not null" json:"strategy"`
+	Makespan   float64    `json:"makespan"`
+	FinishDate *time.Time `json:"finish_date"`
+	TaskCount  int        `gorm:"not null;default:0" json:"task_count"`
+	Options    string     `gorm:"type:text" json:"options"`
+	Schedule   string     `gorm:"type:text" json:"schedule"`
+	IsDeleted  bool       `gorm:"not null;default:false" json:"is_deleted"`
+	CreatedAt  *time.Time `json:"created_at"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+// PlanVersionIndex keeps the versions of the plans sharing a name unique.
+const PlanVersionIndex = "idx_tb_plans_name_version"
+
+func (Plan) TableName() string {
+	return "tb_plans"
+}
+
+func (p *Plan) BeforeCreate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	p.CreatedAt = &now
+	p.UpdatedAt = &now
+	return
+}
+
+func (p *Plan) BeforeUpdate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	p.UpdatedAt = &now
+	return
+}
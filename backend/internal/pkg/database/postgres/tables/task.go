@@ -3,20 +3,36 @@ package tables
 import (
 	"time"
 
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
 	"gorm.io/gorm"
 )
 
 type Task struct {
-	ID         uint       `gorm:"primaryKey;autoIncrement" json:"id"`
-	ExternalID uint       `gorm:"not null" json:"externalId"`
-	Name       string     `json:"name"`
-	Duration   int        `gorm:"not null" json:"duration"`
-	Difficulty int        `gorm:"not null" json:"difficulty"`
-	Provider   string     `gorm:"not null" json:"provider"`
-	CreatedAt  *time.Time `json:"created_at"`
-	UpdatedAt  *time.Time `json:"updated_at"`
+	ID              uint               `gorm:"primaryKey;autoIncrement" json:"id"`
+	ExternalID      uint               `gorm:"not null;uniqueIndex:idx_tb_tasks_provider_key" json:"externalId"`
+	Name            string             `json:"name"`
+	Description     string             `gorm:"type:text;not null;default:''" json:"description"`
+	Duration        int                `gorm:"not null" json:"duration"`
+	Difficulty      int                `gorm:"not null" json:"difficulty"`
+	Priority        int                `gorm:"not null;default:0" json:"priority"`
+	DueDate         *time.Time         `json:"dueDate"`
+	Skills          payload.Skills     `gorm:"type:text" json:"skills"`
+	Provider        string             `gorm:"not null;uniqueIndex:idx_tb_tasks_provider_key" json:"provider"`
+	Status          payload.TaskStatus `gorm:"not null;default:backlog" json:"status"`
+	AssigneeID      *uint              `gorm:"index" json:"assigneeId"`
+	PercentComplete int                `gorm:"not null;default:0" json:"percentComplete"`
+	IsDeleted       bool               `gorm:"not null;default:false" json:"is_deleted"`
+	CreatedAt       *time.Time         `json:"created_at"`
+	UpdatedAt       *time.Time         `json:"updated_at"`
 }
 
+// ProviderKeyIndex keeps the ExternalID of the tasks of a provider unique.
+const ProviderKeyIndex = "idx_tb_tasks_provider_key"
+
+// SearchConfig is the text search configuration used for the search vector
+// of the tasks, maintained by the migrations next to the table.
+const SearchConfig = "english"
+
 func (Task) TableName() string {
 	return "tb_tasks"
 }
@@ -0,0 +1,33 @@
+package tables
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type TaskDependency struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaskID      uint       `gorm:"not null;index" json:"task_id"`
+	DependsOnID uint       `gorm:"not null;index" json:"depends_on_id"`
+	IsDeleted   bool       `gorm:"not null;default:false" json:"is_deleted"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+func (TaskDependency) TableName() string {
+	return "tb_task_dependencies"
+}
+
+func (d *TaskDependency) BeforeCreate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	d.CreatedAt = &now
+	d.UpdatedAt = &now
+	return
+}
+
+func (d *TaskDependency) BeforeUpdate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	d.UpdatedAt = &now
+	return
+}
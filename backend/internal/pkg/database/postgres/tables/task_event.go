@@ -0,0 +1,29 @@
+package tables
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaskEvent is an entry of the history of a task. Events are only ever
+// added, never updated or deleted.
+type TaskEvent struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaskID    uint       `gorm:"not null;index" json:"task_id"`
+	Type      string     `gorm:"not null" json:"type"`
+	Source    string     `gorm:"not null" json:"source"`
+	Actor     string     `gorm:"not null;default:''" json:"actor"`
+	Changes   string     `gorm:"type:text" json:"changes"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (TaskEvent) TableName() string {
+	return "tb_task_events"
+}
+
+func (e *TaskEvent) BeforeCreate(tx *gorm.DB) (err error) {
+	now := time.Now()
+	e.CreatedAt = &now
+	return
+}
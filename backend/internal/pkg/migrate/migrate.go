@@ -1,10 +1,12 @@
 package migrate
 
 import (
+	"fmt"
 	"log"
 
 	"github.com/mehmetali10/task-planner/internal/pkg/database/postgres"
 	"github.com/mehmetali10/task-planner/internal/pkg/database/postgres/tables"
+	"gorm.io/gorm"
 )
 
 func MigrateAndSeed() {
@@ -20,15 +22,79 @@ func MigrateAndSeed() {
 		sqlDB.Close()
 	}()
 
+	// Tasks used to be checked for duplicates before they were inserted, which
+	// could race. Merge the tasks sharing a provider key once, before the key
+	// is made unique.
+	if postgres.DB.Migrator().HasTable(&tables.Task{}) && !postgres.DB.Migrator().HasIndex(&tables.Task{}, tables.ProviderKeyIndex) {
+		merged, err := mergeDuplicateTasks()
+		if err != nil {
+			log.Fatalf("Merging duplicate tasks failed: %v", err)
+		}
+		if merged > 0 {
+			log.Printf("Merged %d duplicate tasks.", merged)
+		}
+	}
+
+	// Plan versions used to be counted before plans were saved, which could
+	// race. Renumber the later of the plans sharing a version once, so the
+	// versions can be unique.
+	if postgres.DB.Migrator().HasTable(&tables.Plan{}) && !postgres.DB.Migrator().HasIndex(&tables.Plan{}, tables.PlanVersionIndex) {
+		result := postgres.DB.Exec(`UPDATE tb_plans p SET "Version" = d."Next"
+			FROM (
+				SELECT a."ID", (SELECT MAX(m."Version") FROM tb_plans m WHERE m."Name" = a."Name")
+					+ row_number() OVER (PARTITION BY a."Name" ORDER BY a."ID") AS "Next"
+				FROM tb_plans a
+				WHERE EXISTS (SELECT 1 FROM tb_plans b WHERE b."Name" = a."Name" AND b."Version" = a."Version" AND b."ID" < a."ID")
+			) d
+			WHERE p."ID" = d."ID"`)
+		if result.Error != nil {
+			log.Fatalf("Renumbering duplicate plan versions failed: %v", result.Error)
+		}
+		if result.RowsAffected > 0 {
+			log.Printf("Renumbered %d duplicate plan versions.", result.RowsAffected)
+		}
+	}
+
 	log.Print("Starting database migration...")
 	if err := postgres.DB.AutoMigrate(
 		&tables.Task{},
 		&tables.Developer{},
+		&tables.Absence{},
+		&tables.TaskDependency{},
+		&tables.Plan{},
+		&tables.TaskEvent{},
 	); err != nil {
 		log.Fatalf("Migration failed: %v", err)
 	}
 	log.Print("Database migration completed successfully.")
 
+	log.Print("Migrating task search index...")
+	// The search vector is generated by the database, so it is not part of the
+	// table struct. Names weigh more than descriptions when ranking matches.
+	searchVector := fmt.Sprintf(
+		`setweight(to_tsvector('%[1]s', coalesce("Name", '')), 'A') || setweight(to_tsvector('%[1]s', "Description"), 'B')`,
+		tables.SearchConfig,
+	)
+	for _, statement := range []string{
+		`ALTER TABLE tb_tasks ADD COLUMN IF NOT EXISTS "SearchVector" tsvector GENERATED ALWAYS AS (` + searchVector + `) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_tb_tasks_search_vector ON tb_tasks USING GIN ("SearchVector")`,
+	} {
+		if err := postgres.DB.Exec(statement).Error; err != nil {
+			log.Fatalf("Search index migration failed: %v", err)
+		}
+	}
+	log.Print("Task search index migrated successfully.")
+
+	log.Print("Migrating developer email index...")
+	// Emails used to be checked before developers were saved, which could
+	// race. Existing duplicates have to be resolved before the index applies.
+	if err := postgres.DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS ` + tables.DeveloperEmailIndex +
+		` ON tb_developers (lower("Email")) WHERE NOT "IsDeleted"`).Error; err != nil {
+		log.Printf("Developer email index migration failed, developers share an email: %v", err)
+	} else {
+		log.Print("Developer email index migrated successfully.")
+	}
+
 	log.Print("Seeding developers...")
 	developers := []tables.Developer{
 		{ID: 1, FirstName: "DEV1", LastName: "One", Email: "dev1@example.com", Capacity: 1},
@@ -43,5 +109,48 @@ func MigrateAndSeed() {
 			log.Printf("Failed to seed developer: %v", err)
 		}
 	}
+
+	// The seeds have fixed IDs, so move the ID sequence past them for developers created through the API
+	if err := postgres.DB.Exec(`SELECT setval(pg_get_serial_sequence('tb_developers', '"ID"'), (SELECT MAX("ID") FROM tb_developers))`).Error; err != nil {
+		log.Printf("Failed to reset developer IDs: %v", err)
+	}
 	log.Print("Developers seeded successfully.")
 }
+
+// duplicateTasks maps every task to the oldest task sharing its provider key,
+// if there is an older one.
+const duplicateTasks = `SELECT a."ID", MIN(b."ID") AS "KeptID"
+	FROM tb_tasks a JOIN tb_tasks b
+	ON a."ExternalID" = b."ExternalID" AND a."Provider" = b."Provider" AND b."ID" < a."ID"
+	GROUP BY a."ID"`
+
+// mergeDuplicateTasks keeps the oldest of the tasks sharing a provider key.
+// The dependencies of the others are moved to it, and the others are deleted
+// under a key of their own, so their history and the plans they are part of
+// still refer to them.
+func mergeDuplicateTasks() (int64, error) {
+	var merged int64
+	err := postgres.DB.Transaction(func(tx *gorm.DB) error {
+		if tx.Migrator().HasTable(&tables.TaskDependency{}) {
+			for _, statement := range []string{
+				`UPDATE tb_task_dependencies d SET "TaskID" = m."KeptID" FROM (` + duplicateTasks + `) m WHERE d."TaskID" = m."ID"`,
+				`UPDATE tb_task_dependencies d SET "DependsOnID" = m."KeptID" FROM (` + duplicateTasks + `) m WHERE d."DependsOnID" = m."ID"`,
+				// Merged tasks may depend on themselves or twice on a task
+				`UPDATE tb_task_dependencies d SET "IsDeleted" = true
+					WHERE NOT d."IsDeleted" AND (d."TaskID" = d."DependsOnID" OR EXISTS (
+						SELECT 1 FROM tb_task_dependencies e
+						WHERE NOT e."IsDeleted" AND e."TaskID" = d."TaskID" AND e."DependsOnID" = d."DependsOnID" AND e."ID" < d."ID"))`,
+			} {
+				if err := tx.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		result := tx.Exec(`UPDATE tb_tasks t SET "IsDeleted" = true, "Provider" = t."Provider" || '#duplicate-' || t."ID"
+			FROM (` + duplicateTasks + `) m WHERE t."ID" = m."ID"`)
+		merged = result.RowsAffected
+		return result.Error
+	})
+	return merged, err
+}
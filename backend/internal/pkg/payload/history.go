@@ -0,0 +1,42 @@
+package payload
+
+import "time"
+
+// TaskEventType is the kind of change recorded in the history of a task.
+type TaskEventType string
+
+const (
+	EventCreated       TaskEventType = "created"
+	EventUpdated       TaskEventType = "updated"
+	EventStatusChanged TaskEventType = "status_changed"
+	EventDeleted       TaskEventType = "deleted"
+)
+
+type (
+	// TaskEvent is a change of a task. Changes holds the old and new value
+	// of every field that changed, keyed by the JSON name of the field.
+	TaskEvent struct {
+		ID        uint                   `json:"id"`
+		TaskID    uint                   `json:"taskId"`
+		Type      TaskEventType          `json:"type"`
+		Source    string                 `json:"source"`
+		Actor     string                 `json:"actor,omitempty"`
+		Changes   map[string]FieldChange `json:"changes,omitempty"`
+		CreatedAt *time.Time             `json:"createdAt"`
+	}
+
+	FieldChange struct {
+		Old interface{} `json:"old"`
+		New interface{} `json:"new"`
+	}
+
+	ListTaskEventsRequest struct {
+		TaskID uint `json:"taskId" validate:"required"`
+		Offset int  `json:"offset" validate:"min=0"`
+		Limit  int  `json:"limit" validate:"min=0,max=1000"`
+	}
+	ListTaskEventsResponse struct {
+		Events []TaskEvent `json:"events"`
+		Total  int64       `json:"total"`
+	}
+)
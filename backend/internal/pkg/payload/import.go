@@ -0,0 +1,60 @@
+package payload
+
+// TaskImportStatus is the outcome of saving a task of a provider.
+type TaskImportStatus string
+
+const (
+	ImportCreated   TaskImportStatus = "created"
+	ImportUpdated   TaskImportStatus = "updated"
+	ImportUnchanged TaskImportStatus = "unchanged"
+	// ImportDeleted is reported for tasks that were deleted, they are not imported again.
+	ImportDeleted TaskImportStatus = "deleted"
+	// ImportDuplicate is reported for a task repeating an earlier one of the same import.
+	ImportDuplicate TaskImportStatus = "duplicate"
+	ImportInvalid   TaskImportStatus = "invalid"
+	ImportFailed    TaskImportStatus = "failed"
+)
+
+type (
+	// TaskImportResult reports what happened to the task at Index of a bulk import.
+	TaskImportResult struct {
+		Index      int              `json:"index"`
+		ExternalID uint             `json:"externalId,omitempty"`
+		Provider   string           `json:"provider,omitempty"`
+		Status     TaskImportStatus `json:"status"`
+		ID         uint             `json:"id,omitempty"`
+		Error      string           `json:"error,omitempty"`
+	}
+
+	BulkCreateTasksResponse struct {
+		Results    []TaskImportResult `json:"results"`
+		Created    int                `json:"created"`
+		Updated    int                `json:"updated"`
+		Unchanged  int                `json:"unchanged"`
+		Deleted    int                `json:"deleted"`
+		Duplicates int                `json:"duplicates"`
+		Invalid    int                `json:"invalid"`
+		Failed     int                `json:"failed"`
+	}
+)
+
+// Add records the result of a task and counts it by its status.
+func (r *BulkCreateTasksResponse) Add(result TaskImportResult) {
+	r.Results = append(r.Results, result)
+	switch result.Status {
+	case ImportCreated:
+		r.Created++
+	case ImportUpdated:
+		r.Updated++
+	case ImportUnchanged:
+		r.Unchanged++
+	case ImportDeleted:
+		r.Deleted++
+	case ImportDuplicate:
+		r.Duplicates++
+	case ImportInvalid:
+		r.Invalid++
+	case ImportFailed:
+		r.Failed++
+	}
+}
@@ -0,0 +1,42 @@
+package payload
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OptionalTime is a time field of a patch request. It tells a field missing
+// from the request, which is kept, apart from an explicit null, which clears
+// the field.
+type OptionalTime struct {
+	// Set is true when the field was given, null included.
+	Set bool
+	// Value is the time given, nil when the field was null.
+	Value *time.Time
+}
+
+// NewOptionalTime returns a field set to t, a nil t clears the field.
+func NewOptionalTime(t *time.Time) OptionalTime {
+	return OptionalTime{Set: true, Value: t}
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It is only called for fields
+// present in the JSON document.
+func (o *OptionalTime) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	o.Value = nil
+	if string(data) == "null" {
+		return nil
+	}
+	var t time.Time
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+	o.Value = &t
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (o OptionalTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Value)
+}
@@ -4,71 +4,408 @@ import "time"
 
 type (
 	Task struct {
-		ID         uint       `json:"id"`
-		ExternalID uint       `json:"externalId"`
-		Name       string     `json:"name"`
-		Duration   int        `json:"duration"`
-		Difficulty int        `json:"difficulty"`
-		Provider   string     `json:"provider"`
-		CreatedAt  *time.Time `json:"createdAt"`
-		UpdatedAt  *time.Time `json:"updatedAt"`
+		ID              uint       `json:"id"`
+		ExternalID      uint       `json:"externalId"`
+		Name            string     `json:"name"`
+		Description     string     `json:"description"`
+		Duration        int        `json:"duration"`
+		Difficulty      int        `json:"difficulty"`
+		Priority        int        `json:"priority"`
+		DueDate         *time.Time `json:"dueDate"`
+		Skills          Skills     `json:"skills"`
+		Provider        string     `json:"provider"`
+		Status          TaskStatus `json:"status"`
+		AssigneeID      *uint      `json:"assigneeId,omitempty"`
+		PercentComplete int        `json:"percentComplete"`
+		CreatedAt       *time.Time `json:"createdAt"`
+		UpdatedAt       *time.Time `json:"updatedAt"`
+	}
+
+	UpdateTaskStatusRequest struct {
+		ID              uint       `json:"-"`
+		Status          TaskStatus `json:"status" validate:"required,oneof=backlog scheduled in_progress blocked done cancelled"`
+		PercentComplete *int       `json:"percentComplete" validate:"omitempty,min=0,max=100"`
+		AssigneeID      *uint      `json:"assigneeId"`
+		// FromStatus is the status the transition was checked against, the
+		// update only applies while the task is still in it. Empty skips the check.
+		FromStatus TaskStatus `json:"-"`
+	}
+
+	GetTaskRequest struct {
+		ID uint `json:"id" validate:"required"`
+	}
+
+	UpdateTaskRequest struct {
+		ID          uint       `json:"-"`
+		Name        string     `json:"name" validate:"required,min=3,max=100"`
+		Description string     `json:"description" validate:"max=5000"`
+		Duration    int        `json:"duration" validate:"required,min=1,max=1000"`
+		Difficulty  int        `json:"difficulty" validate:"required,min=1,max=10"`
+		Priority    int        `json:"priority" validate:"min=0,max=5"`
+		DueDate     *time.Time `json:"dueDate"`
+		Skills      Skills     `json:"skills" validate:"max=20,dive,min=1,max=50"`
+	}
+
+	PatchTaskRequest struct {
+		ID          uint         `json:"-"`
+		Name        *string      `json:"name" validate:"omitempty,min=3,max=100"`
+		Description *string      `json:"description" validate:"omitempty,max=5000"`
+		Duration    *int         `json:"duration" validate:"omitempty,min=1,max=1000"`
+		Difficulty  *int         `json:"difficulty" validate:"omitempty,min=1,max=10"`
+		Priority    *int         `json:"priority" validate:"omitempty,min=0,max=5"`
+		DueDate     OptionalTime `json:"dueDate" swaggertype:"string" format:"date-time" extensions:"x-nullable"`
+		Skills      *Skills      `json:"skills" validate:"omitempty,max=20,dive,min=1,max=50"`
+	}
+
+	DeleteTaskRequest struct {
+		ID uint `json:"id" validate:"required"`
 	}
 
 	CreateTaskRequest struct {
-		ExternalID uint   `json:"externalId" validate:"required"`
-		Name       string `json:"name" validate:"required,min=3,max=100"`
-		Duration   int    `json:"duration" validate:"required,min=1,max=1000"`
-		Difficulty int    `json:"difficulty" validate:"required,min=1,max=10"`
-		Provider   string `json:"provider" validate:"required,min=3,max=150"`
+		ExternalID  uint       `json:"externalId" validate:"required"`
+		Name        string     `json:"name" validate:"required,min=3,max=100"`
+		Description string     `json:"description" validate:"max=5000"`
+		Duration    int        `json:"duration" validate:"required,min=1,max=1000"`
+		Difficulty  int        `json:"difficulty" validate:"required,min=1,max=10"`
+		Priority    int        `json:"priority" validate:"min=0,max=5"`
+		DueDate     *time.Time `json:"dueDate"`
+		Skills      Skills     `json:"skills" validate:"max=20,dive,min=1,max=50"`
+		Provider    string     `json:"provider" validate:"required,min=3,max=150"`
 	}
 	CreateTaskResponse struct {
-		ID        uint       `json:"id"`
-		CreatedAt *time.Time `json:"createdAt"`
+		ID        uint             `json:"id"`
+		CreatedAt *time.Time       `json:"createdAt"`
+		Status    TaskImportStatus `json:"status"`
 	}
 
 	ListTasksRequest struct {
-		Offset int `json:"offset"`
-		Limit  int `json:"limit"`
+		Offset        int          `json:"offset" validate:"min=0"`
+		Limit         int          `json:"limit" validate:"min=0,max=1000"`
+		Cursor        string       `json:"cursor" validate:"max=100"`
+		Sort          string       `json:"sort" validate:"max=200"`
+		Provider      string       `json:"provider" validate:"max=150"`
+		Status        []TaskStatus `json:"status" validate:"max=6,dive,oneof=backlog scheduled in_progress blocked done cancelled"`
+		MinDifficulty int          `json:"minDifficulty" validate:"min=0,max=10"`
+		MaxDifficulty int          `json:"maxDifficulty" validate:"min=0,max=10"`
+		MinDuration   int          `json:"minDuration" validate:"min=0"`
+		MaxDuration   int          `json:"maxDuration" validate:"min=0"`
+		CreatedAfter  *time.Time   `json:"createdAfter"`
 	}
 	ListTasksResponse struct {
-		Tasks []Task `json:"tasks"`
+		Tasks      []Task `json:"tasks"`
+		Total      int64  `json:"total"`
+		NextCursor string `json:"nextCursor,omitempty"`
+		HasMore    bool   `json:"hasMore"`
+	}
+
+	SearchTasksRequest struct {
+		Query  string `json:"q" validate:"required,min=1,max=200"`
+		Offset int    `json:"offset" validate:"min=0"`
+		Limit  int    `json:"limit" validate:"min=0,max=100"`
+	}
+	SearchTasksResponse struct {
+		Results []TaskSearchResult `json:"results"`
+		Total   int64              `json:"total"`
+	}
+	// TaskSearchResult is a task matching a search. The snippet is taken from
+	// the description when it matches, otherwise from the name, with the
+	// matching words wrapped in <mark> tags.
+	TaskSearchResult struct {
+		Task    Task    `json:"task"`
+		Rank    float64 `json:"rank"`
+		Snippet string  `json:"snippet"`
+	}
+)
+
+type (
+	TaskDependency struct {
+		ID          uint       `json:"id"`
+		TaskID      uint       `json:"taskId"`
+		DependsOnID uint       `json:"dependsOnId"`
+		CreatedAt   *time.Time `json:"createdAt"`
+	}
+
+	CreateTaskDependencyRequest struct {
+		TaskID      uint `json:"-" validate:"required"`
+		DependsOnID uint `json:"dependsOnId" validate:"required,nefield=TaskID"`
+	}
+	CreateTaskDependencyResponse struct {
+		ID        uint       `json:"id"`
+		CreatedAt *time.Time `json:"createdAt"`
+	}
+
+	ListTaskDependenciesRequest struct {
+		TaskID uint `json:"taskId"`
+	}
+	ListTaskDependenciesResponse struct {
+		Dependencies []TaskDependency `json:"dependencies"`
+	}
+
+	DeleteTaskDependencyRequest struct {
+		TaskID      uint `json:"taskId" validate:"required"`
+		DependsOnID uint `json:"dependsOnId" validate:"required"`
 	}
 )
 
 type (
 	Assignment struct {
+		Week           uint                      `json:"week"`
+		StartDate      *time.Time                `json:"startDate"`
+		EndDate        *time.Time                `json:"endDate"`
 		DeveloperTasks []DeveloperTaskAssignment `json:"developerTasks"`
 	}
 
 	DeveloperTaskAssignment struct {
-		Developer Developer `json:"developer"`
-		Tasks     []Task    `json:"tasks"`
+		Developer Developer   `json:"developer"`
+		Tasks     []Task      `json:"tasks"`
+		Chunks    []TaskChunk `json:"chunks,omitempty"`
+	}
+
+	TaskChunk struct {
+		TaskID uint    `json:"taskId"`
+		Part   int     `json:"part"`
+		Parts  int     `json:"parts"`
+		Hours  float64 `json:"hours"`
+	}
+
+	TimelineSlot struct {
+		Task  Task      `json:"task"`
+		Week  uint      `json:"week"`
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+		Hours float64   `json:"hours"`
+		Part  int       `json:"part,omitempty"`
+		Parts int       `json:"parts,omitempty"`
+	}
+
+	LateTask struct {
+		Task     Task      `json:"task"`
+		End      time.Time `json:"end"`
+		DaysLate int       `json:"daysLate"`
+	}
+
+	UnassignableTask struct {
+		Task    Task   `json:"task"`
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	}
+
+	DeveloperTimeline struct {
+		Developer Developer      `json:"developer"`
+		Slots     []TimelineSlot `json:"slots"`
 	}
 
 	ScheduleAssignmentRequest struct {
+		Strategy      string   `json:"strategy"`
+		Timeline      bool     `json:"timeline"`
+		HoursPerDay   float64  `json:"hoursPerDay" validate:"min=0,max=24"`
+		DayStartHour  *float64 `json:"dayStartHour" validate:"omitempty,min=0,max=23"`
+		WorkDays      []string `json:"workDays"`
+		Holidays      []string `json:"holidays"`
+		StartDate     string   `json:"startDate"`
+		SkillMatch    string   `json:"skillMatch" validate:"omitempty,oneof=hard soft"`
+		SkillPenalty  float64  `json:"skillPenalty" validate:"omitempty,min=1"`
+		Split         string   `json:"split" validate:"omitempty,oneof=none weeks developers"`
+		BasePlanID    uint     `json:"basePlanId"`
+		FrozenTaskIDs []uint   `json:"frozenTaskIds" validate:"max=10000"`
+		AsOf          string   `json:"asOf"`
+	}
+
+	Reschedule struct {
+		BasePlanID   uint   `json:"basePlanId"`
+		ElapsedWeeks int    `json:"elapsedWeeks"`
+		FrozenTasks  []uint `json:"frozenTasks"`
+		MovedTasks   []uint `json:"movedTasks"`
 	}
 
 	ScheduleAssignmentResponse struct {
-		Assignments          []Assignment `json:"assignments"`
-		MinWeek              uint         `json:"minWeek"`
-		TotalWorkDay         uint         `json:"totalWorkDay"`
-		TotalElapsedWorkHour uint         `json:"totalElapsedWorkHour"`
+		Strategy             string              `json:"strategy"`
+		Makespan             float64             `json:"makespan"`
+		StartDate            *time.Time          `json:"startDate"`
+		FinishDate           *time.Time          `json:"finishDate"`
+		Assignments          []Assignment        `json:"assignments"`
+		Timeline             []DeveloperTimeline `json:"timeline,omitempty"`
+		CriticalPath         []Task              `json:"criticalPath"`
+		CriticalPathHours    float64             `json:"criticalPathHours"`
+		LateTasks            []LateTask          `json:"lateTasks"`
+		Unassignable         []UnassignableTask  `json:"unassignable"`
+		Reschedule           *Reschedule         `json:"reschedule,omitempty"`
+		ConsideredTasks      int                 `json:"consideredTasks"`
+		MinWeek              uint                `json:"minWeek"`
+		TotalWorkDay         uint                `json:"totalWorkDay"`
+		TotalElapsedWorkHour uint                `json:"totalElapsedWorkHour"`
 	}
 )
 
 type (
 	Developer struct {
-		ID        uint       `json:"id"`
-		FirstName string     `json:"firstName"`
-		LastName  string     `json:"lastName"`
-		Capacity  int        `json:"capacity"`
-		Email     string     `json:"email"`
-		CreatedAt *time.Time `json:"createdAt"`
-		UpdatedAt *time.Time `json:"updatedAt"`
+		ID              uint       `json:"id"`
+		FirstName       string     `json:"firstName"`
+		LastName        string     `json:"lastName"`
+		Capacity        int        `json:"capacity"`
+		Email           string     `json:"email"`
+		WeeklyHours     float64    `json:"weeklyHours"`
+		PartTimePercent int        `json:"partTimePercent"`
+		Skills          Skills     `json:"skills"`
+		CreatedAt       *time.Time `json:"createdAt"`
+		UpdatedAt       *time.Time `json:"updatedAt"`
+	}
+
+	CreateDeveloperRequest struct {
+		FirstName       string  `json:"firstName" validate:"required,min=1,max=100"`
+		LastName        string  `json:"lastName" validate:"max=100"`
+		Email           string  `json:"email" validate:"required,email,max=150"`
+		Capacity        int     `json:"capacity" validate:"required,min=1,max=10"`
+		WeeklyHours     float64 `json:"weeklyHours" validate:"min=0,max=168"`
+		PartTimePercent int     `json:"partTimePercent" validate:"min=0,max=100"`
+		Skills          Skills  `json:"skills" validate:"max=20,dive,min=1,max=50"`
+	}
+
+	GetDeveloperRequest struct {
+		ID uint `json:"id" validate:"required"`
+	}
+
+	UpdateDeveloperRequest struct {
+		ID              uint    `json:"-"`
+		FirstName       string  `json:"firstName" validate:"required,min=1,max=100"`
+		LastName        string  `json:"lastName" validate:"max=100"`
+		Email           string  `json:"email" validate:"required,email,max=150"`
+		Capacity        int     `json:"capacity" validate:"required,min=1,max=10"`
+		WeeklyHours     float64 `json:"weeklyHours" validate:"min=0,max=168"`
+		PartTimePercent int     `json:"partTimePercent" validate:"min=0,max=100"`
+		Skills          Skills  `json:"skills" validate:"max=20,dive,min=1,max=50"`
+	}
+
+	DeleteDeveloperRequest struct {
+		ID uint `json:"id" validate:"required"`
 	}
 
 	ListDevelopersRequest  struct{}
 	ListDevelopersResponse struct {
 		Developers []Developer `json:"developers"`
 	}
+
+	UpdateDeveloperSkillsRequest struct {
+		ID     uint   `json:"-" validate:"required"`
+		Skills Skills `json:"skills" validate:"max=20,dive,min=1,max=50"`
+	}
+)
+
+type (
+	Absence struct {
+		ID          uint       `json:"id"`
+		DeveloperID uint       `json:"developerId"`
+		StartDate   time.Time  `json:"startDate"`
+		EndDate     time.Time  `json:"endDate"`
+		Reason      string     `json:"reason"`
+		CreatedAt   *time.Time `json:"createdAt"`
+		UpdatedAt   *time.Time `json:"updatedAt"`
+	}
+
+	CreateAbsenceRequest struct {
+		DeveloperID uint      `json:"-" validate:"required"`
+		StartDate   time.Time `json:"startDate" validate:"required"`
+		EndDate     time.Time `json:"endDate" validate:"required,gtefield=StartDate"`
+		Reason      string    `json:"reason" validate:"max=255"`
+	}
+	CreateAbsenceResponse struct {
+		ID        uint       `json:"id"`
+		CreatedAt *time.Time `json:"createdAt"`
+	}
+
+	GetAbsenceRequest struct {
+		ID          uint `json:"id" validate:"required"`
+		DeveloperID uint `json:"developerId" validate:"required"`
+	}
+
+	ListAbsencesRequest struct {
+		DeveloperID uint `json:"developerId"`
+	}
+	ListAbsencesResponse struct {
+		Absences []Absence `json:"absences"`
+	}
+
+	UpdateAbsenceRequest struct {
+		ID          uint      `json:"-" validate:"required"`
+		DeveloperID uint      `json:"-" validate:"required"`
+		StartDate   time.Time `json:"startDate" validate:"required"`
+		EndDate     time.Time `json:"endDate" validate:"required,gtefield=StartDate"`
+		Reason      string    `json:"reason" validate:"max=255"`
+	}
+
+	DeleteAbsenceRequest struct {
+		ID          uint `json:"id" validate:"required"`
+		DeveloperID uint `json:"developerId" validate:"required"`
+	}
+)
+
+type (
+	Plan struct {
+		ID         uint                       `json:"id"`
+		Name       string                     `json:"name"`
+		Version    int                        `json:"version"`
+		Strategy   string                     `json:"strategy"`
+		Makespan   float64                    `json:"makespan"`
+		FinishDate *time.Time                 `json:"finishDate"`
+		TaskCount  int                        `json:"taskCount"`
+		Options    ScheduleAssignmentRequest  `json:"options"`
+		Schedule   ScheduleAssignmentResponse `json:"schedule"`
+		CreatedAt  *time.Time                 `json:"createdAt"`
+	}
+
+	PlanSummary struct {
+		ID         uint       `json:"id"`
+		Name       string     `json:"name"`
+		Version    int        `json:"version"`
+		Strategy   string     `json:"strategy"`
+		Makespan   float64    `json:"makespan"`
+		FinishDate *time.Time `json:"finishDate"`
+		TaskCount  int        `json:"taskCount"`
+		CreatedAt  *time.Time `json:"createdAt"`
+	}
+
+	CreatePlanRequest struct {
+		Name    string                    `json:"name" validate:"max=100"`
+		Options ScheduleAssignmentRequest `json:"options"`
+	}
+
+	GetPlanRequest struct {
+		ID uint `json:"id" validate:"required"`
+	}
+
+	ListPlansRequest struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}
+	ListPlansResponse struct {
+		Plans []PlanSummary `json:"plans"`
+		Total int64         `json:"total"`
+	}
+
+	DiffPlansRequest struct {
+		ID      uint `json:"id" validate:"required"`
+		OtherID uint `json:"otherId" validate:"required"`
+	}
+	DiffPlansResponse struct {
+		From          PlanSummary  `json:"from"`
+		To            PlanSummary  `json:"to"`
+		MakespanDelta float64      `json:"makespanDelta"`
+		FinishDelta   int          `json:"finishDelta"`
+		Added         []TaskChange `json:"added"`
+		Removed       []TaskChange `json:"removed"`
+		Moved         []TaskChange `json:"moved"`
+		Unchanged     int          `json:"unchanged"`
+	}
+
+	TaskChange struct {
+		Task Task           `json:"task"`
+		From *TaskPlacement `json:"from,omitempty"`
+		To   *TaskPlacement `json:"to,omitempty"`
+	}
+
+	TaskPlacement struct {
+		DeveloperIDs []uint `json:"developerIds"`
+		StartWeek    uint   `json:"startWeek"`
+		EndWeek      uint   `json:"endWeek"`
+	}
 )
@@ -0,0 +1,78 @@
+package payload
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Skills is a set of skill tags such as "backend" or "infra". It is stored as
+// a JSON array in a text column.
+type Skills []string
+
+// NewSkills normalizes skill tags: they are trimmed, lower-cased and deduplicated.
+func NewSkills(tags ...string) Skills {
+	skills := Skills{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		skills = append(skills, tag)
+	}
+	return skills
+}
+
+// Missing returns how many of the required skills are not in s.
+func (s Skills) Missing(required Skills) int {
+	missing := 0
+	for _, skill := range required {
+		if !s.Has(skill) {
+			missing++
+		}
+	}
+	return missing
+}
+
+// Has reports whether s contains a skill, ignoring case.
+func (s Skills) Has(skill string) bool {
+	for _, own := range s {
+		if strings.EqualFold(own, skill) {
+			return true
+		}
+	}
+	return false
+}
+
+// Value implements driver.Valuer.
+func (s Skills) Value() (driver.Value, error) {
+	if s == nil {
+		s = Skills{}
+	}
+	b, err := json.Marshal(s)
+	return string(b), err
+}
+
+// Scan implements sql.Scanner.
+func (s *Skills) Scan(value interface{}) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*s = Skills{}
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("cannot scan %T into Skills", value)
+	}
+	if len(data) == 0 {
+		*s = Skills{}
+		return nil
+	}
+	return json.Unmarshal(data, s)
+}
@@ -0,0 +1,58 @@
+package payload
+
+// TaskStatus is the stage of a task's lifecycle.
+type TaskStatus string
+
+const (
+	StatusBacklog    TaskStatus = "backlog"
+	StatusScheduled  TaskStatus = "scheduled"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusBlocked    TaskStatus = "blocked"
+	StatusDone       TaskStatus = "done"
+	StatusCancelled  TaskStatus = "cancelled"
+)
+
+// transitions lists the statuses a task may move to from each status.
+var transitions = map[TaskStatus][]TaskStatus{
+	StatusBacklog:    {StatusScheduled, StatusInProgress, StatusBlocked, StatusCancelled},
+	StatusScheduled:  {StatusBacklog, StatusInProgress, StatusBlocked, StatusCancelled},
+	StatusInProgress: {StatusScheduled, StatusBlocked, StatusDone, StatusCancelled},
+	StatusBlocked:    {StatusBacklog, StatusScheduled, StatusInProgress, StatusCancelled},
+	StatusDone:       {StatusInProgress},
+	StatusCancelled:  {StatusBacklog},
+}
+
+// Valid reports whether s is a known status.
+func (s TaskStatus) Valid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether a task may move from s to next. Staying in
+// the same status is allowed, so progress can be reported on its own. A task
+// without a status is in the backlog.
+func (s TaskStatus) CanTransitionTo(next TaskStatus) bool {
+	if s == "" {
+		s = StatusBacklog
+	}
+	if s == next {
+		return next.Valid()
+	}
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Closed reports whether no more work is planned for a task in status s.
+func (s TaskStatus) Closed() bool {
+	return s == StatusDone || s == StatusCancelled
+}
+
+// Remaining returns the share of a task that is still left to do.
+func (t Task) Remaining() float64 {
+	percent := min(max(t.PercentComplete, 0), 100)
+	return 1 - float64(percent)/100
+}
@@ -2,12 +2,57 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/mehmetali10/task-planner/internal/pkg/payload"
 )
 
+// ErrNotFound is returned when a requested record does not exist.
+var ErrNotFound = errors.New("record not found")
+
+// ErrAlreadyExists is returned when a record conflicts with an existing one.
+var ErrAlreadyExists = errors.New("record already exists")
+
+// ErrChanged is returned when a conditional update finds that the record
+// changed since it was read.
+var ErrChanged = errors.New("record changed concurrently")
+
+// ErrInvalidQuery is returned when a list query cannot be run, e.g. because of
+// an unknown sort field or a malformed cursor.
+var ErrInvalidQuery = errors.New("invalid query")
+
 type Repository interface {
 	CreateTask(ctx context.Context, req payload.CreateTaskRequest) (payload.CreateTaskResponse, error)
+	UpsertTasks(ctx context.Context, reqs []payload.CreateTaskRequest) ([]payload.TaskImportResult, error)
 	ListTasks(ctx context.Context, req payload.ListTasksRequest) (payload.ListTasksResponse, error)
+	// IterateTasks calls fn with all tasks that are not deleted, in batches
+	// ordered by ID. An error returned by fn stops the iteration.
+	IterateTasks(ctx context.Context, fn func(tasks []payload.Task) error) error
+	SearchTasks(ctx context.Context, req payload.SearchTasksRequest) (payload.SearchTasksResponse, error)
+	GetTask(ctx context.Context, req payload.GetTaskRequest) (payload.Task, error)
+	UpdateTask(ctx context.Context, req payload.UpdateTaskRequest) (payload.Task, error)
+	PatchTask(ctx context.Context, req payload.PatchTaskRequest) (payload.Task, error)
+	DeleteTask(ctx context.Context, req payload.DeleteTaskRequest) error
+	UpdateTaskStatus(ctx context.Context, req payload.UpdateTaskStatusRequest) (payload.Task, error)
+	ListTaskEvents(ctx context.Context, req payload.ListTaskEventsRequest) (payload.ListTaskEventsResponse, error)
+	CreateTaskDependency(ctx context.Context, req payload.CreateTaskDependencyRequest) (payload.CreateTaskDependencyResponse, error)
+	ListTaskDependencies(ctx context.Context, req payload.ListTaskDependenciesRequest) (payload.ListTaskDependenciesResponse, error)
+	DeleteTaskDependency(ctx context.Context, req payload.DeleteTaskDependencyRequest) error
+
 	ListDevelopers(ctx context.Context, req payload.ListDevelopersRequest) (payload.ListDevelopersResponse, error)
+	CreateDeveloper(ctx context.Context, req payload.CreateDeveloperRequest) (payload.Developer, error)
+	GetDeveloper(ctx context.Context, req payload.GetDeveloperRequest) (payload.Developer, error)
+	UpdateDeveloper(ctx context.Context, req payload.UpdateDeveloperRequest) (payload.Developer, error)
+	DeleteDeveloper(ctx context.Context, req payload.DeleteDeveloperRequest) error
+	UpdateDeveloperSkills(ctx context.Context, req payload.UpdateDeveloperSkillsRequest) (payload.Developer, error)
+
+	CreateAbsence(ctx context.Context, req payload.CreateAbsenceRequest) (payload.CreateAbsenceResponse, error)
+	GetAbsence(ctx context.Context, req payload.GetAbsenceRequest) (payload.Absence, error)
+	ListAbsences(ctx context.Context, req payload.ListAbsencesRequest) (payload.ListAbsencesResponse, error)
+	UpdateAbsence(ctx context.Context, req payload.UpdateAbsenceRequest) (payload.Absence, error)
+	DeleteAbsence(ctx context.Context, req payload.DeleteAbsenceRequest) error
+
+	CreatePlan(ctx context.Context, plan payload.Plan) (payload.Plan, error)
+	GetPlan(ctx context.Context, req payload.GetPlanRequest) (payload.Plan, error)
+	ListPlans(ctx context.Context, req payload.ListPlansRequest) (payload.ListPlansResponse, error)
 }
@@ -0,0 +1,33 @@
+package repository
+
+import "context"
+
+// Sources of the changes recorded in the history of tasks.
+const (
+	SourceAPI     = "api"
+	SourceUser    = "user"
+	SourceConsole = "console"
+)
+
+// Origin tells where a change comes from. The actor names the user for
+// changes of a user and the provider URL for changes of the console.
+type Origin struct {
+	Source string
+	Actor  string
+}
+
+type originKey struct{}
+
+// WithOrigin returns a context recording changes as coming from origin.
+func WithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+// OriginFrom returns the origin of the changes made with ctx. Changes without
+// an origin come from the API.
+func OriginFrom(ctx context.Context) Origin {
+	if origin, ok := ctx.Value(originKey{}).(Origin); ok {
+		return origin
+	}
+	return Origin{Source: SourceAPI}
+}
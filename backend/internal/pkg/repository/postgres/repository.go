@@ -2,9 +2,16 @@ package postgres_repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mehmetali10/task-planner/pkg/automapper"
 	"github.com/mehmetali10/task-planner/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/mehmetali10/task-planner/internal/pkg/config"
 	"github.com/mehmetali10/task-planner/internal/pkg/database/postgres"
@@ -25,82 +32,998 @@ func NewPostgresRepo() repository.Repository {
 	}
 }
 
-// CreateTask implements repository.Repository.
+// CreateTask implements repository.Repository. A task whose ExternalID and
+// Provider match an existing task updates that task, see UpsertTasks.
 func (p *PostgresRepo) CreateTask(ctx context.Context, req payload.CreateTaskRequest) (payload.CreateTaskResponse, error) {
 	p.logger.Trace(
-		"Checking if task already exists externalId=%s, provider=%s",
+		"Creating task externalId=%v, provider=%v",
 		req.ExternalID,
 		req.Provider,
 	)
-
-	// Check if a task with the same ExternalID and Provider already exists
-	existingTasks, err := postgres.Read[[]payload.CreateTaskResponse, tables.Task](
-		ctx,
-		map[string]interface{}{
-			"ExternalID": req.ExternalID,
-			"Provider":   req.Provider,
-		},
-		1, // Limit to 1 result
-		0, // Offset
-	)
+	rows, err := p.upsertTasks(ctx, []payload.CreateTaskRequest{req})
 	if err != nil {
 		p.logger.Error(
-			"Failed to check existing task externalId=%v, provider=%v: error=%v",
+			"Failed to create task externalId=%v, provider=%v: error=%v",
 			req.ExternalID,
 			req.Provider,
 			err,
 		)
 		return payload.CreateTaskResponse{}, err
 	}
-
-	if len(existingTasks) > 0 {
-		p.logger.Warn(
-			"Task already exists externalId=%v, provider=%v",
-			req.ExternalID,
-			req.Provider,
-		)
-		return payload.CreateTaskResponse{}, fmt.Errorf("task with externalId=%v and provider=%v already exists", req.ExternalID, req.Provider)
-	}
-
 	p.logger.Trace(
-		"Creating new task externalId=%s, provider=%s",
+		"Task saved successfully externalId=%v, provider=%v, status=%v",
 		req.ExternalID,
 		req.Provider,
+		rows[0].result.Status,
 	)
-	resp, err := postgres.Create[payload.CreateTaskResponse, tables.Task](ctx, req)
+	return payload.CreateTaskResponse{ID: rows[0].result.ID, CreatedAt: rows[0].createdAt, Status: rows[0].result.Status}, nil
+}
+
+// UpsertTasks implements repository.Repository. The tasks are saved in a
+// single transaction, so callers should split large imports into batches.
+//
+// A task is identified by its ExternalID and Provider. When it exists, the
+// fields the provider owns are updated if they changed, while its status,
+// progress and assignee are kept. Deleted tasks are left deleted, so a
+// provider does not import them again. A task repeating an earlier one of
+// the batch is a duplicate and ignored.
+func (p *PostgresRepo) UpsertTasks(ctx context.Context, reqs []payload.CreateTaskRequest) ([]payload.TaskImportResult, error) {
+	p.logger.Trace("Saving %v tasks", len(reqs))
+	rows, err := p.upsertTasks(ctx, reqs)
 	if err != nil {
-		p.logger.Error(
-			"Failed to create task externalId=%v, provider=%v: error=%v",
-			req.ExternalID,
-			req.Provider,
-			err,
-		)
-		return resp, err
+		p.logger.Error("Failed to save %v tasks: error=%v", len(reqs), err)
+		return nil, err
 	}
-	p.logger.Trace(
-		"Task created successfully externalId=%v, provider=%v",
-		req.ExternalID,
-		req.Provider,
-	)
-	return resp, err
+	results := make([]payload.TaskImportResult, len(rows))
+	for i, row := range rows {
+		results[i] = row.result
+	}
+	p.logger.Trace("Saved %v tasks successfully", len(reqs))
+	return results, nil
+}
+
+// upsertTasks saves tasks as described by UpsertTasks, keeping the creation
+// time of the tasks next to their results.
+func (p *PostgresRepo) upsertTasks(ctx context.Context, reqs []payload.CreateTaskRequest) ([]upsertedTask, error) {
+	rows := make([]upsertedTask, len(reqs))
+	var unique []payload.CreateTaskRequest
+	index := make(map[taskKey]int, len(reqs))
+	for i, req := range reqs {
+		rows[i].result = payload.TaskImportResult{Index: i, ExternalID: req.ExternalID, Provider: req.Provider}
+		key := taskKey{req.ExternalID, req.Provider}
+		if _, ok := index[key]; ok {
+			rows[i].result.Status = payload.ImportDuplicate
+			continue
+		}
+		index[key] = i
+		unique = append(unique, req)
+	}
+	if len(unique) == 0 {
+		return rows, nil
+	}
+
+	// Tasks inserted by another transaction after the existing tasks were
+	// read are missed by the read, so the transaction is run again to read
+	// them before they are updated.
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = p.upsertTaskRows(ctx, reqs, unique, index, rows)
+		if !errors.Is(err, errTasksRaced) || attempt == upsertAttempts {
+			break
+		}
+		p.logger.Warn("Tasks were saved at the same time, retrying %v tasks", len(unique))
+	}
+	return rows, err
+}
+
+// upsertTaskRows saves the unique tasks of reqs in one transaction, filling
+// the rows of their results. It returns errTasksRaced when a task was inserted
+// by another transaction after the existing tasks were read.
+func (p *PostgresRepo) upsertTaskRows(ctx context.Context, reqs, unique []payload.CreateTaskRequest, index map[taskKey]int, rows []upsertedTask) error {
+	return postgres.Transaction(ctx, func(tx *gorm.DB) error {
+		// Lock the existing tasks to record what changed. The upsert only
+		// returns the inserted and updated tasks, the others are unchanged
+		// or deleted.
+		keys := make([][]interface{}, len(unique))
+		for i, req := range unique {
+			keys[i] = []interface{}{req.ExternalID, req.Provider}
+		}
+		var existing []tables.Task
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(`("ExternalID", "Provider") IN ?`, keys).
+			Find(&existing).Error; err != nil {
+			return err
+		}
+		previous := make(map[taskKey]payload.Task, len(existing))
+		for _, task := range existing {
+			key := taskKey{task.ExternalID, task.Provider}
+			var old payload.Task
+			automapper.MapLoose(task, &old)
+			previous[key] = old
+
+			i := index[key]
+			rows[i].result.ID = task.ID
+			rows[i].result.Status = payload.ImportUnchanged
+			if task.IsDeleted {
+				rows[i].result.Status = payload.ImportDeleted
+			}
+			rows[i].createdAt = task.CreatedAt
+		}
+
+		var saved []taskUpsertRow
+		query, args := upsertTasksQuery(unique, time.Now())
+		if err := tx.Raw(query, args...).Scan(&saved).Error; err != nil {
+			return err
+		}
+
+		// Every task was either read or inserted here, unless another
+		// transaction inserted it in between. Its changes are then unknown.
+		inserted := 0
+		for _, row := range saved {
+			if row.Inserted {
+				inserted++
+			} else if _, ok := previous[taskKey{row.ExternalID, row.Provider}]; !ok {
+				return errTasksRaced
+			}
+		}
+		if len(previous)+inserted < len(unique) {
+			return errTasksRaced
+		}
+
+		events := make([]tables.TaskEvent, 0, len(saved))
+		for _, row := range saved {
+			key := taskKey{row.ExternalID, row.Provider}
+			i := index[key]
+			rows[i].result.ID = row.ID
+			rows[i].createdAt = row.CreatedAt
+
+			var task payload.Task
+			automapper.MapLoose(reqs[i], &task)
+			eventType, changes := payload.EventUpdated, taskChanges(previous[key], task, providerColumns)
+			rows[i].result.Status = payload.ImportUpdated
+			if row.Inserted {
+				eventType, changes = payload.EventCreated, createdChanges(task, providerColumns)
+				rows[i].result.Status = payload.ImportCreated
+			}
+			event, err := newTaskEvent(ctx, row.ID, eventType, changes)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		return tx.Create(&events).Error
+	})
+}
+
+// upsertAttempts is how often tasks are saved when another transaction
+// inserted some of them at the same time.
+const upsertAttempts = 3
+
+// errTasksRaced reports tasks inserted by another transaction while saving.
+var errTasksRaced = errors.New("tasks were inserted at the same time")
+
+// taskKey identifies a task of a provider.
+type taskKey struct {
+	externalID uint
+	provider   string
+}
+
+// upsertedTask is the result of saving a task with its creation time.
+type upsertedTask struct {
+	result    payload.TaskImportResult
+	createdAt *time.Time
 }
 
-// ListTasks implements repository.Repository.
+// ListTasks implements repository.Repository. A page holds the tasks after the
+// cursor if one is given, otherwise the tasks after the offset.
 func (p *PostgresRepo) ListTasks(ctx context.Context, req payload.ListTasksRequest) (payload.ListTasksResponse, error) {
-	p.logger.Trace("Listing tasks")
-	tasks, err := postgres.Read[[]payload.Task, tables.Task](ctx, map[string]interface{}{}, req.Limit, req.Offset)
+	p.logger.Trace("Listing tasks sort=%v, cursor=%v, offset=%v, limit=%v", req.Sort, req.Cursor, req.Offset, req.Limit)
+	keys, err := parseTaskSort(req.Sort)
+	if err != nil {
+		p.logger.Warn("Invalid task list sort=%v: error=%v", req.Sort, err)
+		return payload.ListTasksResponse{}, err
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultTaskLimit
+	}
+	query := postgres.PageQuery{
+		Where: taskFilters(req),
+		Order: orderClauses(keys),
+		// One more task than asked for tells whether another page follows
+		Limit:  limit + 1,
+		Offset: req.Offset,
+	}
+	if req.Cursor != "" {
+		if req.Offset != 0 {
+			return payload.ListTasksResponse{}, fmt.Errorf("cursor and offset cannot be combined: %w", repository.ErrInvalidQuery)
+		}
+		values, err := decodeCursor(keys, req.Cursor)
+		if err != nil {
+			p.logger.Warn("Invalid task list cursor=%v: error=%v", req.Cursor, err)
+			return payload.ListTasksResponse{}, err
+		}
+		query.After = afterCondition(keys, values)
+	}
+
+	tasks, total, err := postgres.ReadPage[[]payload.Task, tables.Task](ctx, query)
 	if err != nil {
 		p.logger.Error("Failed to list tasks: error=%v", err)
+		return payload.ListTasksResponse{}, err
+	}
+
+	resp := payload.ListTasksResponse{Tasks: tasks, Total: total}
+	if len(tasks) > limit {
+		resp.Tasks = tasks[:limit]
+		resp.HasMore = true
+		resp.NextCursor = encodeCursor(keys, resp.Tasks[limit-1])
 	}
-	return payload.ListTasksResponse{Tasks: tasks}, err
+	return resp, nil
+}
+
+// IterateTasks implements repository.Repository. The tasks are read through a
+// server-side cursor, however many there are.
+func (p *PostgresRepo) IterateTasks(ctx context.Context, fn func(tasks []payload.Task) error) error {
+	p.logger.Trace("Iterating tasks batchSize=%v", iterateBatchSize)
+	count := 0
+	err := postgres.Iterate(ctx, iterateBatchSize, func(tasks []payload.Task) error {
+		count += len(tasks)
+		return fn(tasks)
+	}, `SELECT * FROM tb_tasks WHERE "IsDeleted" = false ORDER BY "ID"`)
+	if err != nil {
+		p.logger.Error("Failed to iterate tasks after %v tasks: error=%v", count, err)
+		return err
+	}
+	p.logger.Trace("Tasks iterated count=%v", count)
+	return nil
+}
+
+// SearchTasks implements repository.Repository. Matches are ranked by the
+// search vector maintained by the migrations, best first.
+func (p *PostgresRepo) SearchTasks(ctx context.Context, req payload.SearchTasksRequest) (payload.SearchTasksResponse, error) {
+	p.logger.Trace("Searching tasks query=%v, offset=%v, limit=%v", req.Query, req.Offset, req.Limit)
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	config := fmt.Sprintf("'%s'::regconfig", tables.SearchConfig)
+	total, err := postgres.ReadRaw[int64](ctx,
+		`SELECT count(*) FROM tb_tasks WHERE "IsDeleted" = false AND "SearchVector" @@ websearch_to_tsquery(`+config+`, ?)`,
+		req.Query,
+	)
+	if err != nil {
+		p.logger.Error("Failed to count search results query=%v: error=%v", req.Query, err)
+		return payload.SearchTasksResponse{}, err
+	}
+
+	rows, err := postgres.ReadRaw[[]taskSearchRow](ctx, `
+		SELECT t.*,
+			ts_rank_cd(t."SearchVector", q) AS "Rank",
+			CASE WHEN to_tsvector(`+config+`, t."Description") @@ q
+				THEN ts_headline(`+config+`, t."Description", q, ?)
+				ELSE ts_headline(`+config+`, COALESCE(t."Name", ''), q, ?)
+			END AS "Snippet"
+		FROM tb_tasks t, websearch_to_tsquery(`+config+`, ?) q
+		WHERE t."IsDeleted" = false AND t."SearchVector" @@ q
+		ORDER BY "Rank" DESC, t."ID"
+		LIMIT ? OFFSET ?`,
+		snippetOptions, snippetOptions, req.Query, limit, req.Offset,
+	)
+	if err != nil {
+		p.logger.Error("Failed to search tasks query=%v: error=%v", req.Query, err)
+		return payload.SearchTasksResponse{}, err
+	}
+
+	resp := payload.SearchTasksResponse{Results: make([]payload.TaskSearchResult, len(rows)), Total: total}
+	for i, row := range rows {
+		resp.Results[i] = payload.TaskSearchResult{Task: row.Task, Rank: row.Rank, Snippet: row.Snippet}
+	}
+	return resp, nil
+}
+
+// GetTask implements repository.Repository.
+func (p *PostgresRepo) GetTask(ctx context.Context, req payload.GetTaskRequest) (payload.Task, error) {
+	p.logger.Trace("Getting task id=%v", req.ID)
+	tasks, err := postgres.Read[[]payload.Task, tables.Task](ctx, map[string]interface{}{"ID": req.ID, "IsDeleted": false}, 1, 0)
+	if err != nil {
+		p.logger.Error("Failed to get task id=%v: error=%v", req.ID, err)
+		return payload.Task{}, err
+	}
+	if len(tasks) == 0 {
+		return payload.Task{}, fmt.Errorf("task with id=%v: %w", req.ID, repository.ErrNotFound)
+	}
+	return tasks[0], nil
+}
+
+// UpdateTask implements repository.Repository. All editable fields are
+// replaced, clearing the ones missing from the request.
+func (p *PostgresRepo) UpdateTask(ctx context.Context, req payload.UpdateTaskRequest) (payload.Task, error) {
+	p.logger.Trace("Updating task id=%v", req.ID)
+	return p.updateTaskColumns(ctx, req.ID, payload.EventUpdated, map[string]interface{}{
+		"Name":        req.Name,
+		"Description": req.Description,
+		"Duration":    req.Duration,
+		"Difficulty":  req.Difficulty,
+		"Priority":    req.Priority,
+		"DueDate":     req.DueDate,
+		"Skills":      req.Skills,
+	})
+}
+
+// PatchTask implements repository.Repository. Only the fields given in the
+// request are changed.
+func (p *PostgresRepo) PatchTask(ctx context.Context, req payload.PatchTaskRequest) (payload.Task, error) {
+	p.logger.Trace("Patching task id=%v", req.ID)
+	columns := map[string]interface{}{}
+	if req.Name != nil {
+		columns["Name"] = *req.Name
+	}
+	if req.Description != nil {
+		columns["Description"] = *req.Description
+	}
+	if req.Duration != nil {
+		columns["Duration"] = *req.Duration
+	}
+	if req.Difficulty != nil {
+		columns["Difficulty"] = *req.Difficulty
+	}
+	if req.Priority != nil {
+		columns["Priority"] = *req.Priority
+	}
+	if req.DueDate.Set {
+		columns["DueDate"] = req.DueDate.Value
+	}
+	if req.Skills != nil {
+		columns["Skills"] = *req.Skills
+	}
+	if len(columns) == 0 {
+		return p.GetTask(ctx, payload.GetTaskRequest{ID: req.ID})
+	}
+	return p.updateTaskColumns(ctx, req.ID, payload.EventUpdated, columns)
+}
+
+// updateTaskColumns sets columns of a task that is not deleted and returns the
+// updated task. The columns that changed are recorded in the history of the task.
+func (p *PostgresRepo) updateTaskColumns(ctx context.Context, id uint, eventType payload.TaskEventType, columns map[string]interface{}, conditions ...postgres.Condition) (payload.Task, error) {
+	var task payload.Task
+	err := postgres.Transaction(ctx, func(tx *gorm.DB) error {
+		old, err := lockTask(tx, id)
+		if err != nil {
+			return err
+		}
+		update := tx.Model(&tables.Task{}).Where(`"ID" = ?`, id)
+		for _, condition := range conditions {
+			update = update.Where(condition.Query, condition.Args...)
+		}
+		updated := update.Updates(columns)
+		if updated.Error != nil {
+			return updated.Error
+		}
+		if updated.RowsAffected == 0 {
+			return fmt.Errorf("task with id=%v: %w", id, repository.ErrChanged)
+		}
+		if err := tx.Model(&tables.Task{}).Where(`"ID" = ?`, id).Take(&task).Error; err != nil {
+			return err
+		}
+
+		changes := taskChanges(old, task, sortedColumns(columns))
+		if len(changes) == 0 {
+			return nil
+		}
+		event, err := newTaskEvent(ctx, id, eventType, changes)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&event).Error
+	})
+	if err != nil {
+		p.logger.Error("Failed to update task id=%v: error=%v", id, err)
+		return payload.Task{}, err
+	}
+	return task, nil
+}
+
+// DeleteTask implements repository.Repository. The dependencies of and on
+// the task are deleted with it.
+func (p *PostgresRepo) DeleteTask(ctx context.Context, req payload.DeleteTaskRequest) error {
+	p.logger.Trace("Deleting task id=%v", req.ID)
+	err := postgres.Transaction(ctx, func(tx *gorm.DB) error {
+		deleted := tx.Model(&tables.Task{}).
+			Where(`"ID" = ? AND "IsDeleted" = ?`, req.ID, false).
+			Update("IsDeleted", true)
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		if deleted.RowsAffected == 0 {
+			return fmt.Errorf("task with id=%v: %w", req.ID, repository.ErrNotFound)
+		}
+
+		if err := tx.Model(&tables.TaskDependency{}).
+			Where(`("TaskID" = ? OR "DependsOnID" = ?) AND "IsDeleted" = ?`, req.ID, req.ID, false).
+			Update("IsDeleted", true).Error; err != nil {
+			return err
+		}
+
+		event, err := newTaskEvent(ctx, req.ID, payload.EventDeleted, nil)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&event).Error
+	})
+	if err != nil {
+		p.logger.Error("Failed to delete task id=%v: error=%v", req.ID, err)
+		return err
+	}
+	p.logger.Trace("Task deleted successfully id=%v", req.ID)
+	return nil
+}
+
+// ListTaskEvents implements repository.Repository. Events are returned oldest
+// first. The history of deleted tasks can be read as well.
+func (p *PostgresRepo) ListTaskEvents(ctx context.Context, req payload.ListTaskEventsRequest) (payload.ListTaskEventsResponse, error) {
+	p.logger.Trace("Listing events of task id=%v", req.TaskID)
+	tasks, err := postgres.Read[[]payload.Task, tables.Task](ctx, map[string]interface{}{"ID": req.TaskID}, 1, 0)
+	if err != nil {
+		p.logger.Error("Failed to check task id=%v: error=%v", req.TaskID, err)
+		return payload.ListTaskEventsResponse{}, err
+	}
+	if len(tasks) == 0 {
+		return payload.ListTaskEventsResponse{}, fmt.Errorf("task with id=%v: %w", req.TaskID, repository.ErrNotFound)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultEventLimit
+	}
+	rows, total, err := postgres.ReadPage[[]tables.TaskEvent, tables.TaskEvent](ctx, postgres.PageQuery{
+		Where:  []postgres.Condition{{Query: `"TaskID" = ?`, Args: []any{req.TaskID}}},
+		Order:  []string{`"ID"`},
+		Limit:  limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		p.logger.Error("Failed to list events of task id=%v: error=%v", req.TaskID, err)
+		return payload.ListTaskEventsResponse{}, err
+	}
+
+	resp := payload.ListTaskEventsResponse{Events: make([]payload.TaskEvent, 0, len(rows)), Total: total}
+	for _, row := range rows {
+		event, err := taskEventFromRow(row)
+		if err != nil {
+			p.logger.Error("Failed to read event of task id=%v: error=%v", req.TaskID, err)
+			return payload.ListTaskEventsResponse{}, err
+		}
+		resp.Events = append(resp.Events, event)
+	}
+	return resp, nil
+}
+
+// UpdateTaskStatus implements repository.Repository. An assignee ID of zero
+// removes the assignee.
+func (p *PostgresRepo) UpdateTaskStatus(ctx context.Context, req payload.UpdateTaskStatusRequest) (payload.Task, error) {
+	p.logger.Trace("Updating task status id=%v, status=%v", req.ID, req.Status)
+
+	columns := map[string]interface{}{"Status": req.Status}
+	if req.PercentComplete != nil {
+		columns["PercentComplete"] = *req.PercentComplete
+	}
+	if req.AssigneeID != nil {
+		columns["AssigneeID"] = nil
+		if *req.AssigneeID != 0 {
+			developers, err := postgres.Read[[]payload.Developer, tables.Developer](ctx, map[string]interface{}{"ID": *req.AssigneeID, "IsDeleted": false}, 1, 0)
+			if err != nil {
+				p.logger.Error("Failed to check developer developerId=%v: error=%v", *req.AssigneeID, err)
+				return payload.Task{}, err
+			}
+			if len(developers) == 0 {
+				p.logger.Warn("Developer not found developerId=%v", *req.AssigneeID)
+				return payload.Task{}, fmt.Errorf("developer with id=%v: %w", *req.AssigneeID, repository.ErrNotFound)
+			}
+			columns["AssigneeID"] = *req.AssigneeID
+		}
+	}
+
+	var conditions []postgres.Condition
+	if req.FromStatus != "" {
+		conditions = append(conditions, postgres.Condition{Query: `"Status" = ?`, Args: []any{req.FromStatus}})
+	}
+	return p.updateTaskColumns(ctx, req.ID, payload.EventStatusChanged, columns, conditions...)
+}
+
+// CreateTaskDependency implements repository.Repository.
+func (p *PostgresRepo) CreateTaskDependency(ctx context.Context, req payload.CreateTaskDependencyRequest) (payload.CreateTaskDependencyResponse, error) {
+	p.logger.Trace("Creating task dependency taskId=%v, dependsOnId=%v", req.TaskID, req.DependsOnID)
+
+	for _, id := range []uint{req.TaskID, req.DependsOnID} {
+		tasks, err := postgres.Read[[]payload.Task, tables.Task](ctx, map[string]interface{}{"ID": id, "IsDeleted": false}, 1, 0)
+		if err != nil {
+			p.logger.Error("Failed to check task id=%v: error=%v", id, err)
+			return payload.CreateTaskDependencyResponse{}, err
+		}
+		if len(tasks) == 0 {
+			p.logger.Warn("Task not found id=%v", id)
+			return payload.CreateTaskDependencyResponse{}, fmt.Errorf("task with id=%v: %w", id, repository.ErrNotFound)
+		}
+	}
+
+	existing, err := postgres.Read[[]payload.TaskDependency, tables.TaskDependency](
+		ctx,
+		map[string]interface{}{
+			"TaskID":      req.TaskID,
+			"DependsOnID": req.DependsOnID,
+			"IsDeleted":   false,
+		},
+		1, // Limit to 1 result
+		0, // Offset
+	)
+	if err != nil {
+		p.logger.Error("Failed to check existing task dependency taskId=%v, dependsOnId=%v: error=%v", req.TaskID, req.DependsOnID, err)
+		return payload.CreateTaskDependencyResponse{}, err
+	}
+	if len(existing) > 0 {
+		p.logger.Warn("Task dependency already exists taskId=%v, dependsOnId=%v", req.TaskID, req.DependsOnID)
+		return payload.CreateTaskDependencyResponse{}, fmt.Errorf("task %v already depends on task %v: %w", req.TaskID, req.DependsOnID, repository.ErrAlreadyExists)
+	}
+
+	resp, err := postgres.Create[payload.CreateTaskDependencyResponse, tables.TaskDependency](ctx, req)
+	if err != nil {
+		p.logger.Error("Failed to create task dependency taskId=%v, dependsOnId=%v: error=%v", req.TaskID, req.DependsOnID, err)
+		return resp, err
+	}
+	p.logger.Trace("Task dependency created successfully id=%v", resp.ID)
+	return resp, nil
+}
+
+// readAll reads every row of a query through a server-side cursor, the
+// scheduler relies on these lists being complete.
+func readAll[Dest any](ctx context.Context, query string, args ...any) ([]Dest, error) {
+	var rows []Dest
+	err := postgres.Iterate(ctx, iterateBatchSize, func(batch []Dest) error {
+		rows = append(rows, batch...)
+		return nil
+	}, query, args...)
+	return rows, err
+}
+
+// ListTaskDependencies implements repository.Repository. All dependencies are
+// returned, however many there are.
+func (p *PostgresRepo) ListTaskDependencies(ctx context.Context, req payload.ListTaskDependenciesRequest) (payload.ListTaskDependenciesResponse, error) {
+	p.logger.Trace("Listing task dependencies taskId=%v", req.TaskID)
+	query := `SELECT * FROM tb_task_dependencies WHERE "IsDeleted" = false`
+	var args []any
+	if req.TaskID != 0 {
+		query += ` AND "TaskID" = ?`
+		args = append(args, req.TaskID)
+	}
+	dependencies, err := readAll[payload.TaskDependency](ctx, query+` ORDER BY "ID"`, args...)
+	if err != nil {
+		p.logger.Error("Failed to list task dependencies taskId=%v: error=%v", req.TaskID, err)
+	}
+	return payload.ListTaskDependenciesResponse{Dependencies: dependencies}, err
+}
+
+// DeleteTaskDependency implements repository.Repository.
+func (p *PostgresRepo) DeleteTaskDependency(ctx context.Context, req payload.DeleteTaskDependencyRequest) error {
+	p.logger.Trace("Deleting task dependency taskId=%v, dependsOnId=%v", req.TaskID, req.DependsOnID)
+	deleted, err := postgres.Delete[tables.TaskDependency](
+		ctx,
+		map[string]interface{}{
+			"TaskID":      req.TaskID,
+			"DependsOnID": req.DependsOnID,
+		},
+	)
+	if err != nil {
+		p.logger.Error("Failed to delete task dependency taskId=%v, dependsOnId=%v: error=%v", req.TaskID, req.DependsOnID, err)
+		return err
+	}
+	if deleted == 0 {
+		return fmt.Errorf("dependency of task %v on task %v: %w", req.TaskID, req.DependsOnID, repository.ErrNotFound)
+	}
+	return nil
 }
 
 // ListDevelopers implements repository.Repository.
 func (p *PostgresRepo) ListDevelopers(ctx context.Context, req payload.ListDevelopersRequest) (payload.ListDevelopersResponse, error) {
 	p.logger.Trace("Listing developers")
-	developers, err := postgres.Read[[]payload.Developer, tables.Developer](ctx, map[string]interface{}{}, 10000, 0)
+	developers, err := postgres.Read[[]payload.Developer, tables.Developer](ctx, map[string]interface{}{"IsDeleted": false}, 10000, 0)
 	if err != nil {
 		p.logger.Error("Failed to list developers: error=%v", err)
 	}
 	return payload.ListDevelopersResponse{Developers: developers}, err
 }
+
+// UpdateDeveloperSkills implements repository.Repository.
+func (p *PostgresRepo) UpdateDeveloperSkills(ctx context.Context, req payload.UpdateDeveloperSkillsRequest) (payload.Developer, error) {
+	p.logger.Trace("Updating developer skills id=%v, skills=%v", req.ID, req.Skills)
+	updated, err := postgres.UpdateColumns[tables.Developer](
+		ctx,
+		map[string]interface{}{"ID": req.ID, "IsDeleted": false},
+		map[string]interface{}{"Skills": req.Skills},
+	)
+	if err != nil {
+		p.logger.Error("Failed to update developer skills id=%v: error=%v", req.ID, err)
+		return payload.Developer{}, err
+	}
+	if updated == 0 {
+		return payload.Developer{}, fmt.Errorf("developer with id=%v: %w", req.ID, repository.ErrNotFound)
+	}
+	return p.GetDeveloper(ctx, payload.GetDeveloperRequest{ID: req.ID})
+}
+
+// CreateDeveloper implements repository.Repository.
+func (p *PostgresRepo) CreateDeveloper(ctx context.Context, req payload.CreateDeveloperRequest) (payload.Developer, error) {
+	p.logger.Trace("Creating developer email=%v", req.Email)
+	if err := p.checkDeveloperEmail(ctx, req.Email, 0); err != nil {
+		return payload.Developer{}, err
+	}
+
+	resp, err := postgres.Create[payload.Developer, tables.Developer](ctx, req)
+	if isUniqueViolation(err, tables.DeveloperEmailIndex) {
+		p.logger.Warn("Developer email already in use email=%v", req.Email)
+		return payload.Developer{}, fmt.Errorf("developer with email=%v: %w", req.Email, repository.ErrAlreadyExists)
+	}
+	if err != nil {
+		p.logger.Error("Failed to create developer email=%v: error=%v", req.Email, err)
+		return resp, err
+	}
+	p.logger.Trace("Developer created successfully id=%v, email=%v", resp.ID, req.Email)
+	return resp, nil
+}
+
+// GetDeveloper implements repository.Repository.
+func (p *PostgresRepo) GetDeveloper(ctx context.Context, req payload.GetDeveloperRequest) (payload.Developer, error) {
+	p.logger.Trace("Getting developer id=%v", req.ID)
+	developers, err := postgres.Read[[]payload.Developer, tables.Developer](ctx, map[string]interface{}{"ID": req.ID, "IsDeleted": false}, 1, 0)
+	if err != nil {
+		p.logger.Error("Failed to get developer id=%v: error=%v", req.ID, err)
+		return payload.Developer{}, err
+	}
+	if len(developers) == 0 {
+		return payload.Developer{}, fmt.Errorf("developer with id=%v: %w", req.ID, repository.ErrNotFound)
+	}
+	return developers[0], nil
+}
+
+// UpdateDeveloper implements repository.Repository. All fields are replaced.
+func (p *PostgresRepo) UpdateDeveloper(ctx context.Context, req payload.UpdateDeveloperRequest) (payload.Developer, error) {
+	p.logger.Trace("Updating developer id=%v", req.ID)
+	if err := p.checkDeveloperEmail(ctx, req.Email, req.ID); err != nil {
+		return payload.Developer{}, err
+	}
+
+	updated, err := postgres.UpdateColumns[tables.Developer](
+		ctx,
+		map[string]interface{}{"ID": req.ID, "IsDeleted": false},
+		map[string]interface{}{
+			"FirstName":       req.FirstName,
+			"LastName":        req.LastName,
+			"Email":           req.Email,
+			"Capacity":        req.Capacity,
+			"WeeklyHours":     req.WeeklyHours,
+			"PartTimePercent": req.PartTimePercent,
+			"Skills":          req.Skills,
+		},
+	)
+	if isUniqueViolation(err, tables.DeveloperEmailIndex) {
+		p.logger.Warn("Developer email already in use email=%v", req.Email)
+		return payload.Developer{}, fmt.Errorf("developer with email=%v: %w", req.Email, repository.ErrAlreadyExists)
+	}
+	if err != nil {
+		p.logger.Error("Failed to update developer id=%v: error=%v", req.ID, err)
+		return payload.Developer{}, err
+	}
+	if updated == 0 {
+		return payload.Developer{}, fmt.Errorf("developer with id=%v: %w", req.ID, repository.ErrNotFound)
+	}
+	return p.GetDeveloper(ctx, payload.GetDeveloperRequest{ID: req.ID})
+}
+
+// DeleteDeveloper implements repository.Repository. The tasks assigned to the
+// developer are unassigned, recording it in their history, and the absences of
+// the developer are deleted with it.
+func (p *PostgresRepo) DeleteDeveloper(ctx context.Context, req payload.DeleteDeveloperRequest) error {
+	p.logger.Trace("Deleting developer id=%v", req.ID)
+	err := postgres.Transaction(ctx, func(tx *gorm.DB) error {
+		deleted := tx.Model(&tables.Developer{}).
+			Where(`"ID" = ? AND "IsDeleted" = ?`, req.ID, false).
+			Update("IsDeleted", true)
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		if deleted.RowsAffected == 0 {
+			return fmt.Errorf("developer with id=%v: %w", req.ID, repository.ErrNotFound)
+		}
+
+		var assigned []payload.Task
+		if err := tx.Model(&tables.Task{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where(`"AssigneeID" = ? AND "IsDeleted" = ?`, req.ID, false).
+			Order(`"ID"`).
+			Find(&assigned).Error; err != nil {
+			return err
+		}
+		for _, task := range assigned {
+			if err := tx.Model(&tables.Task{}).Where(`"ID" = ?`, task.ID).Update("AssigneeID", nil).Error; err != nil {
+				return err
+			}
+			unassigned := task
+			unassigned.AssigneeID = nil
+			event, err := newTaskEvent(ctx, task.ID, payload.EventUpdated, taskChanges(task, unassigned, []string{"AssigneeID"}))
+			if err != nil {
+				return err
+			}
+			if err := tx.Create(&event).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&tables.Absence{}).
+			Where(`"DeveloperID" = ? AND "IsDeleted" = ?`, req.ID, false).
+			Update("IsDeleted", true).Error
+	})
+	if err != nil {
+		p.logger.Error("Failed to delete developer id=%v: error=%v", req.ID, err)
+		return err
+	}
+	p.logger.Trace("Developer deleted successfully id=%v", req.ID)
+	return nil
+}
+
+// isUniqueViolation reports whether err is a violation of the unique index.
+func isUniqueViolation(err error, index string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == index
+}
+
+// checkDeveloperEmail returns ErrAlreadyExists when another developer, other
+// than the one with the given ID, uses the email. It fails early with a clear
+// error, the unique index on the emails decides when requests race.
+func (p *PostgresRepo) checkDeveloperEmail(ctx context.Context, email string, id uint) error {
+	developers, err := postgres.Read[[]payload.Developer, tables.Developer](ctx, map[string]interface{}{"Email": email, "IsDeleted": false}, 2, 0)
+	if err != nil {
+		p.logger.Error("Failed to check developer email=%v: error=%v", email, err)
+		return err
+	}
+	for _, developer := range developers {
+		if developer.ID != id {
+			p.logger.Warn("Developer email already in use email=%v, developerId=%v", email, developer.ID)
+			return fmt.Errorf("developer with email=%v: %w", email, repository.ErrAlreadyExists)
+		}
+	}
+	return nil
+}
+
+// CreateAbsence implements repository.Repository.
+func (p *PostgresRepo) CreateAbsence(ctx context.Context, req payload.CreateAbsenceRequest) (payload.CreateAbsenceResponse, error) {
+	p.logger.Trace("Creating absence developerId=%v", req.DeveloperID)
+
+	developers, err := postgres.Read[[]payload.Developer, tables.Developer](ctx, map[string]interface{}{"ID": req.DeveloperID, "IsDeleted": false}, 1, 0)
+	if err != nil {
+		p.logger.Error("Failed to check developer developerId=%v: error=%v", req.DeveloperID, err)
+		return payload.CreateAbsenceResponse{}, err
+	}
+	if len(developers) == 0 {
+		p.logger.Warn("Developer not found developerId=%v", req.DeveloperID)
+		return payload.CreateAbsenceResponse{}, fmt.Errorf("developer with id=%v: %w", req.DeveloperID, repository.ErrNotFound)
+	}
+
+	resp, err := postgres.Create[payload.CreateAbsenceResponse, tables.Absence](ctx, req)
+	if err != nil {
+		p.logger.Error("Failed to create absence developerId=%v: error=%v", req.DeveloperID, err)
+		return resp, err
+	}
+	p.logger.Trace("Absence created successfully id=%v, developerId=%v", resp.ID, req.DeveloperID)
+	return resp, nil
+}
+
+// GetAbsence implements repository.Repository.
+func (p *PostgresRepo) GetAbsence(ctx context.Context, req payload.GetAbsenceRequest) (payload.Absence, error) {
+	p.logger.Trace("Getting absence id=%v, developerId=%v", req.ID, req.DeveloperID)
+	absences, err := postgres.Read[[]payload.Absence, tables.Absence](
+		ctx,
+		map[string]interface{}{
+			"ID":          req.ID,
+			"DeveloperID": req.DeveloperID,
+			"IsDeleted":   false,
+		},
+		1, // Limit to 1 result
+		0, // Offset
+	)
+	if err != nil {
+		p.logger.Error("Failed to get absence id=%v, developerId=%v: error=%v", req.ID, req.DeveloperID, err)
+		return payload.Absence{}, err
+	}
+	if len(absences) == 0 {
+		return payload.Absence{}, fmt.Errorf("absence with id=%v and developerId=%v: %w", req.ID, req.DeveloperID, repository.ErrNotFound)
+	}
+	return absences[0], nil
+}
+
+// ListAbsences implements repository.Repository. All absences are returned,
+// however many there are.
+func (p *PostgresRepo) ListAbsences(ctx context.Context, req payload.ListAbsencesRequest) (payload.ListAbsencesResponse, error) {
+	p.logger.Trace("Listing absences developerId=%v", req.DeveloperID)
+	query := `SELECT * FROM tb_absences WHERE "IsDeleted" = false`
+	var args []any
+	if req.DeveloperID != 0 {
+		query += ` AND "DeveloperID" = ?`
+		args = append(args, req.DeveloperID)
+	}
+	absences, err := readAll[payload.Absence](ctx, query+` ORDER BY "ID"`, args...)
+	if err != nil {
+		p.logger.Error("Failed to list absences developerId=%v: error=%v", req.DeveloperID, err)
+	}
+	return payload.ListAbsencesResponse{Absences: absences}, err
+}
+
+// UpdateAbsence implements repository.Repository.
+func (p *PostgresRepo) UpdateAbsence(ctx context.Context, req payload.UpdateAbsenceRequest) (payload.Absence, error) {
+	getReq := payload.GetAbsenceRequest{ID: req.ID, DeveloperID: req.DeveloperID}
+	if _, err := p.GetAbsence(ctx, getReq); err != nil {
+		return payload.Absence{}, err
+	}
+
+	p.logger.Trace("Updating absence id=%v, developerId=%v", req.ID, req.DeveloperID)
+	_, err := postgres.Update[payload.Absence, tables.Absence](
+		ctx,
+		map[string]interface{}{
+			"ID":          req.ID,
+			"DeveloperID": req.DeveloperID,
+		},
+		req,
+	)
+	if err != nil {
+		p.logger.Error("Failed to update absence id=%v, developerId=%v: error=%v", req.ID, req.DeveloperID, err)
+		return payload.Absence{}, err
+	}
+	return p.GetAbsence(ctx, getReq)
+}
+
+// DeleteAbsence implements repository.Repository.
+func (p *PostgresRepo) DeleteAbsence(ctx context.Context, req payload.DeleteAbsenceRequest) error {
+	p.logger.Trace("Deleting absence id=%v, developerId=%v", req.ID, req.DeveloperID)
+	deleted, err := postgres.Delete[tables.Absence](
+		ctx,
+		map[string]interface{}{
+			"ID":          req.ID,
+			"DeveloperID": req.DeveloperID,
+		},
+	)
+	if err != nil {
+		p.logger.Error("Failed to delete absence id=%v, developerId=%v: error=%v", req.ID, req.DeveloperID, err)
+		return err
+	}
+	if deleted == 0 {
+		return fmt.Errorf("absence with id=%v and developerId=%v: %w", req.ID, req.DeveloperID, repository.ErrNotFound)
+	}
+	return nil
+}
+
+const (
+	// defaultPlanLimit is the number of plans returned when a request has no limit.
+	defaultPlanLimit = 100
+	// planVersionAttempts is how often a plan is saved when its version is taken.
+	planVersionAttempts = 3
+)
+
+// CreatePlan implements repository.Repository. The plan is stored as the next
+// version of the plans sharing its name. Plans saved at the same time may
+// count the same version, the unique index rejects all but one of them and
+// the others are saved again with the next version.
+func (p *PostgresRepo) CreatePlan(ctx context.Context, plan payload.Plan) (payload.Plan, error) {
+	p.logger.Trace("Creating plan name=%v, strategy=%v", plan.Name, plan.Strategy)
+
+	options, err := json.Marshal(plan.Options)
+	if err != nil {
+		return payload.Plan{}, err
+	}
+	schedule, err := json.Marshal(plan.Schedule)
+	if err != nil {
+		return payload.Plan{}, err
+	}
+
+	var row tables.Plan
+	for attempt := 1; ; attempt++ {
+		row = tables.Plan{
+			Name:       plan.Name,
+			Strategy:   plan.Strategy,
+			Makespan:   plan.Makespan,
+			FinishDate: plan.FinishDate,
+			TaskCount:  plan.TaskCount,
+			Options:    string(options),
+			Schedule:   string(schedule),
+		}
+		err = postgres.Transaction(ctx, func(tx *gorm.DB) error {
+			if err := tx.Model(&tables.Plan{}).
+				Select(`COALESCE(MAX("Version"), 0) + 1`).
+				Where(`"Name" = ?`, plan.Name).
+				Scan(&row.Version).Error; err != nil {
+				return err
+			}
+			return tx.Create(&row).Error
+		})
+		if !isUniqueViolation(err, tables.PlanVersionIndex) || attempt == planVersionAttempts {
+			break
+		}
+		p.logger.Warn("Plan version taken, retrying name=%v, version=%v", plan.Name, row.Version)
+	}
+	if err != nil {
+		p.logger.Error("Failed to create plan name=%v: error=%v", plan.Name, err)
+		return payload.Plan{}, err
+	}
+	p.logger.Trace("Plan created successfully id=%v, name=%v, version=%v", row.ID, row.Name, row.Version)
+	return planFromRow(row)
+}
+
+// GetPlan implements repository.Repository.
+func (p *PostgresRepo) GetPlan(ctx context.Context, req payload.GetPlanRequest) (payload.Plan, error) {
+	p.logger.Trace("Getting plan id=%v", req.ID)
+	plans, err := postgres.Read[[]tables.Plan, tables.Plan](
+		ctx,
+		map[string]interface{}{
+			"ID":        req.ID,
+			"IsDeleted": false,
+		},
+		1, // Limit to 1 result
+		0, // Offset
+	)
+	if err != nil {
+		p.logger.Error("Failed to get plan id=%v: error=%v", req.ID, err)
+		return payload.Plan{}, err
+	}
+	if len(plans) == 0 {
+		return payload.Plan{}, fmt.Errorf("plan with id=%v: %w", req.ID, repository.ErrNotFound)
+	}
+	return planFromRow(plans[0])
+}
+
+// ListPlans implements repository.Repository. Plans are returned newest first
+// without their schedules.
+func (p *PostgresRepo) ListPlans(ctx context.Context, req payload.ListPlansRequest) (payload.ListPlansResponse, error) {
+	p.logger.Trace("Listing plans limit=%v, offset=%v", req.Limit, req.Offset)
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultPlanLimit
+	}
+	plans, total, err := postgres.ReadPage[[]payload.PlanSummary, tables.Plan](ctx, postgres.PageQuery{
+		Where:  []postgres.Condition{{Query: `"IsDeleted" = ?`, Args: []any{false}}},
+		Order:  []string{`"ID" DESC`},
+		Limit:  limit,
+		Offset: req.Offset,
+	})
+	if err != nil {
+		p.logger.Error("Failed to list plans: error=%v", err)
+		return payload.ListPlansResponse{}, err
+	}
+	return payload.ListPlansResponse{Plans: plans, Total: total}, nil
+}
+
+// planFromRow decodes the options and schedule stored with a plan.
+func planFromRow(row tables.Plan) (payload.Plan, error) {
+	plan := payload.Plan{
+		ID:         row.ID,
+		Name:       row.Name,
+		Version:    row.Version,
+		Strategy:   row.Strategy,
+		Makespan:   row.Makespan,
+		FinishDate: row.FinishDate,
+		TaskCount:  row.TaskCount,
+		CreatedAt:  row.CreatedAt,
+	}
+	if row.Options != "" {
+		if err := json.Unmarshal([]byte(row.Options), &plan.Options); err != nil {
+			return payload.Plan{}, fmt.Errorf("decode options of plan %v: %w", row.ID, err)
+		}
+	}
+	if row.Schedule != "" {
+		if err := json.Unmarshal([]byte(row.Schedule), &plan.Schedule); err != nil {
+			return payload.Plan{}, fmt.Errorf("decode schedule of plan %v: %w", row.ID, err)
+		}
+	}
+	return plan, nil
+}
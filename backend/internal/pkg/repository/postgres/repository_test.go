@@ -2,9 +2,12 @@ package postgres_repository
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
 	"github.com/mehmetali10/task-planner/internal/pkg/testcontainer"
 	"github.com/stretchr/testify/require"
 )
@@ -19,9 +22,9 @@ func TestRepository(t *testing.T) {
 
 	t.Run("CreateTask", func(t *testing.T) {
 		tests := []struct {
-			name          string
-			input         payload.CreateTaskRequest
-			expectedError bool
+			name           string
+			input          payload.CreateTaskRequest
+			expectedStatus payload.TaskImportStatus
 		}{
 			{
 				name: "CreateTask_Success",
@@ -32,7 +35,7 @@ func TestRepository(t *testing.T) {
 					Difficulty: 3,
 					Provider:   "Test Provider",
 				},
-				expectedError: false,
+				expectedStatus: payload.ImportCreated,
 			},
 			{
 				name: "CreateTask_TaskAlreadyExists",
@@ -43,24 +46,35 @@ func TestRepository(t *testing.T) {
 					Difficulty: 3,
 					Provider:   "Test Provider",
 				},
-				expectedError: true,
+				expectedStatus: payload.ImportUnchanged,
+			},
+			{
+				name: "CreateTask_TaskChanged",
+				input: payload.CreateTaskRequest{
+					ExternalID: 123,
+					Name:       "New Task",
+					Duration:   8,
+					Difficulty: 3,
+					Provider:   "Test Provider",
+				},
+				expectedStatus: payload.ImportUpdated,
 			},
 		}
 
 		// Run each test case
+		var id uint
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
 				resp, err := repo.CreateTask(context.Background(), tt.input)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedStatus, resp.Status)
 
-				// Handle errors and validate response
-				if tt.expectedError {
-					require.Error(t, err)
-				} else {
-					require.NoError(t, err)
-
-					// Validate that the response ID is greater than 0
-					require.Greater(t, resp.ID, uint(0))
+				// Validate that the response ID is greater than 0 and the same for every save
+				require.Greater(t, resp.ID, uint(0))
+				if id == 0 {
+					id = resp.ID
 				}
+				require.Equal(t, id, resp.ID)
 			})
 		}
 	})
@@ -139,4 +153,563 @@ func TestRepository(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("DeveloperSkills", func(t *testing.T) {
+		_, err := repo.UpdateDeveloperSkills(context.Background(), payload.UpdateDeveloperSkillsRequest{ID: 999, Skills: payload.Skills{"backend"}})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+
+		developer, err := repo.UpdateDeveloperSkills(context.Background(), payload.UpdateDeveloperSkillsRequest{ID: 1, Skills: payload.Skills{"backend", "infra"}})
+		require.NoError(t, err)
+		require.Equal(t, payload.Skills{"backend", "infra"}, developer.Skills)
+
+		// An empty list removes all skills
+		developer, err = repo.UpdateDeveloperSkills(context.Background(), payload.UpdateDeveloperSkillsRequest{ID: 1, Skills: payload.Skills{}})
+		require.NoError(t, err)
+		require.Empty(t, developer.Skills)
+	})
+
+	t.Run("Absences", func(t *testing.T) {
+		start := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2026, 11, 6, 0, 0, 0, 0, time.UTC)
+
+		// Creating an absence for an unknown developer fails with not found
+		_, err := repo.CreateAbsence(context.Background(), payload.CreateAbsenceRequest{
+			DeveloperID: 999,
+			StartDate:   start,
+			EndDate:     end,
+		})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+
+		created, err := repo.CreateAbsence(context.Background(), payload.CreateAbsenceRequest{
+			DeveloperID: 1,
+			StartDate:   start,
+			EndDate:     end,
+			Reason:      "Vacation",
+		})
+		require.NoError(t, err)
+		require.Greater(t, created.ID, uint(0))
+
+		absence, err := repo.GetAbsence(context.Background(), payload.GetAbsenceRequest{ID: created.ID, DeveloperID: 1})
+		require.NoError(t, err)
+		require.Equal(t, "Vacation", absence.Reason)
+
+		updated, err := repo.UpdateAbsence(context.Background(), payload.UpdateAbsenceRequest{
+			ID:          created.ID,
+			DeveloperID: 1,
+			StartDate:   start,
+			EndDate:     end.AddDate(0, 0, 3),
+			Reason:      "Extended vacation",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Extended vacation", updated.Reason)
+
+		list, err := repo.ListAbsences(context.Background(), payload.ListAbsencesRequest{DeveloperID: 1})
+		require.NoError(t, err)
+		require.Len(t, list.Absences, 1)
+
+		require.NoError(t, repo.DeleteAbsence(context.Background(), payload.DeleteAbsenceRequest{ID: created.ID, DeveloperID: 1}))
+		require.ErrorIs(t, repo.DeleteAbsence(context.Background(), payload.DeleteAbsenceRequest{ID: created.ID, DeveloperID: 1}), repository.ErrNotFound)
+
+		_, err = repo.GetAbsence(context.Background(), payload.GetAbsenceRequest{ID: created.ID, DeveloperID: 1})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("TaskDependencies", func(t *testing.T) {
+		second, err := repo.CreateTask(context.Background(), payload.CreateTaskRequest{
+			ExternalID: 124,
+			Name:       "Blocked Task",
+			Duration:   2,
+			Difficulty: 2,
+			Provider:   "Test Provider",
+		})
+		require.NoError(t, err)
+
+		// Depending on an unknown task fails with not found
+		_, err = repo.CreateTaskDependency(context.Background(), payload.CreateTaskDependencyRequest{TaskID: second.ID, DependsOnID: 999})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+
+		created, err := repo.CreateTaskDependency(context.Background(), payload.CreateTaskDependencyRequest{TaskID: second.ID, DependsOnID: 1})
+		require.NoError(t, err)
+		require.Greater(t, created.ID, uint(0))
+
+		_, err = repo.CreateTaskDependency(context.Background(), payload.CreateTaskDependencyRequest{TaskID: second.ID, DependsOnID: 1})
+		require.ErrorIs(t, err, repository.ErrAlreadyExists)
+
+		list, err := repo.ListTaskDependencies(context.Background(), payload.ListTaskDependenciesRequest{TaskID: second.ID})
+		require.NoError(t, err)
+		require.Len(t, list.Dependencies, 1)
+		require.Equal(t, uint(1), list.Dependencies[0].DependsOnID)
+
+		require.NoError(t, repo.DeleteTaskDependency(context.Background(), payload.DeleteTaskDependencyRequest{TaskID: second.ID, DependsOnID: 1}))
+		require.ErrorIs(t, repo.DeleteTaskDependency(context.Background(), payload.DeleteTaskDependencyRequest{TaskID: second.ID, DependsOnID: 1}), repository.ErrNotFound)
+	})
+
+	t.Run("TaskStatus", func(t *testing.T) {
+		task, err := repo.GetTask(context.Background(), payload.GetTaskRequest{ID: 1})
+		require.NoError(t, err)
+		require.Equal(t, payload.StatusBacklog, task.Status)
+
+		half, assignee := 50, uint(1)
+		task, err = repo.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: 1, Status: payload.StatusInProgress, PercentComplete: &half, AssigneeID: &assignee})
+		require.NoError(t, err)
+		require.Equal(t, payload.StatusInProgress, task.Status)
+		require.Equal(t, 50, task.PercentComplete)
+		require.Equal(t, &assignee, task.AssigneeID)
+
+		// An assignee of zero removes the assignee
+		none := uint(0)
+		task, err = repo.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: 1, Status: payload.StatusBacklog, AssigneeID: &none})
+		require.NoError(t, err)
+		require.Nil(t, task.AssigneeID)
+		require.Equal(t, 50, task.PercentComplete)
+
+		unknown := uint(999)
+		_, err = repo.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: 1, Status: payload.StatusBacklog, AssigneeID: &unknown})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+		_, err = repo.GetTask(context.Background(), payload.GetTaskRequest{ID: 999})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("TaskCRUD", func(t *testing.T) {
+		created, err := repo.CreateTask(context.Background(), payload.CreateTaskRequest{
+			ExternalID: 789,
+			Name:       "Editable Task",
+			Duration:   3,
+			Difficulty: 2,
+			Priority:   4,
+			Provider:   "Test Provider",
+		})
+		require.NoError(t, err)
+		_, err = repo.CreateTaskDependency(context.Background(), payload.CreateTaskDependencyRequest{TaskID: created.ID, DependsOnID: 1})
+		require.NoError(t, err)
+
+		// Replacing a task clears the fields missing from the request
+		updated, err := repo.UpdateTask(context.Background(), payload.UpdateTaskRequest{ID: created.ID, Name: "Edited Task", Duration: 4, Difficulty: 5})
+		require.NoError(t, err)
+		require.Equal(t, "Edited Task", updated.Name)
+		require.Equal(t, 0, updated.Priority)
+
+		difficulty := 7
+		patched, err := repo.PatchTask(context.Background(), payload.PatchTaskRequest{ID: created.ID, Difficulty: &difficulty})
+		require.NoError(t, err)
+		require.Equal(t, 7, patched.Difficulty)
+		require.Equal(t, 4, patched.Duration)
+
+		require.NoError(t, repo.DeleteTask(context.Background(), payload.DeleteTaskRequest{ID: created.ID}))
+		_, err = repo.GetTask(context.Background(), payload.GetTaskRequest{ID: created.ID})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+		_, err = repo.PatchTask(context.Background(), payload.PatchTaskRequest{ID: created.ID, Difficulty: &difficulty})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+		require.ErrorIs(t, repo.DeleteTask(context.Background(), payload.DeleteTaskRequest{ID: created.ID}), repository.ErrNotFound)
+
+		list, err := repo.ListTasks(context.Background(), payload.ListTasksRequest{})
+		require.NoError(t, err)
+		for _, task := range list.Tasks {
+			require.NotEqual(t, created.ID, task.ID)
+		}
+		deps, err := repo.ListTaskDependencies(context.Background(), payload.ListTaskDependenciesRequest{TaskID: created.ID})
+		require.NoError(t, err)
+		require.Empty(t, deps.Dependencies)
+	})
+
+	t.Run("UpsertTasks", func(t *testing.T) {
+		existing, err := repo.CreateTask(context.Background(), payload.CreateTaskRequest{
+			ExternalID: 9200, Name: "Existing Task", Duration: 2, Difficulty: 2, Provider: "Bulk Provider",
+		})
+		require.NoError(t, err)
+		changed, err := repo.CreateTask(context.Background(), payload.CreateTaskRequest{
+			ExternalID: 9203, Name: "Changed Task", Duration: 2, Difficulty: 2, Provider: "Bulk Provider",
+		})
+		require.NoError(t, err)
+		_, err = repo.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: changed.ID, Status: payload.StatusInProgress})
+		require.NoError(t, err)
+		deleted, err := repo.CreateTask(context.Background(), payload.CreateTaskRequest{
+			ExternalID: 9204, Name: "Deleted Task", Duration: 2, Difficulty: 2, Provider: "Bulk Provider",
+		})
+		require.NoError(t, err)
+		require.NoError(t, repo.DeleteTask(context.Background(), payload.DeleteTaskRequest{ID: deleted.ID}))
+
+		results, err := repo.UpsertTasks(context.Background(), []payload.CreateTaskRequest{
+			{ExternalID: 9201, Name: "Bulk Task 1", Duration: 2, Difficulty: 2, Provider: "Bulk Provider"},
+			{ExternalID: 9200, Name: "Existing Task", Duration: 2, Difficulty: 2, Provider: "Bulk Provider"},
+			{ExternalID: 9202, Name: "Bulk Task 2", Duration: 3, Difficulty: 1, Provider: "Bulk Provider", Skills: payload.Skills{"backend"}},
+			{ExternalID: 9201, Name: "Bulk Task 1 again", Duration: 2, Difficulty: 2, Provider: "Bulk Provider"},
+			{ExternalID: 9201, Name: "Other Provider", Duration: 2, Difficulty: 2, Provider: "Other Bulk Provider"},
+			{ExternalID: 9203, Name: "Changed Task", Duration: 5, Difficulty: 2, Provider: "Bulk Provider"},
+			{ExternalID: 9204, Name: "Deleted Task", Duration: 5, Difficulty: 2, Provider: "Bulk Provider"},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 7)
+		statuses := make([]payload.TaskImportStatus, len(results))
+		for i, result := range results {
+			require.Equal(t, i, result.Index)
+			statuses[i] = result.Status
+		}
+		require.Equal(t, []payload.TaskImportStatus{
+			payload.ImportCreated, payload.ImportUnchanged, payload.ImportCreated, payload.ImportDuplicate,
+			payload.ImportCreated, payload.ImportUpdated, payload.ImportDeleted,
+		}, statuses)
+		require.Equal(t, existing.ID, results[1].ID)
+		require.Equal(t, changed.ID, results[5].ID)
+		require.Equal(t, deleted.ID, results[6].ID)
+
+		task, err := repo.GetTask(context.Background(), payload.GetTaskRequest{ID: results[2].ID})
+		require.NoError(t, err)
+		require.Equal(t, "Bulk Task 2", task.Name)
+		require.Equal(t, payload.StatusBacklog, task.Status)
+		require.Equal(t, payload.Skills{"backend"}, task.Skills)
+
+		// Updates keep what the planner owns, such as the status
+		task, err = repo.GetTask(context.Background(), payload.GetTaskRequest{ID: changed.ID})
+		require.NoError(t, err)
+		require.Equal(t, 5, task.Duration)
+		require.Equal(t, payload.StatusInProgress, task.Status)
+
+		_, err = repo.GetTask(context.Background(), payload.GetTaskRequest{ID: deleted.ID})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("ListTasksQuery", func(t *testing.T) {
+		for i := 1; i <= 5; i++ {
+			_, err := repo.CreateTask(context.Background(), payload.CreateTaskRequest{
+				ExternalID: uint(9000 + i),
+				Name:       "Query Task",
+				Duration:   i,
+				Difficulty: i,
+				Priority:   i % 2,
+				Provider:   "Query Provider",
+			})
+			require.NoError(t, err)
+		}
+
+		filtered, err := repo.ListTasks(context.Background(), payload.ListTasksRequest{
+			Provider:      "Query Provider",
+			MinDifficulty: 2,
+			MaxDuration:   4,
+			Sort:          "-difficulty",
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), filtered.Total)
+		require.False(t, filtered.HasMore)
+		require.Empty(t, filtered.NextCursor)
+		require.Equal(t, []int{4, 3, 2}, []int{filtered.Tasks[0].Difficulty, filtered.Tasks[1].Difficulty, filtered.Tasks[2].Difficulty})
+
+		// Walking the pages with the cursor returns every task once in order,
+		// also when a task is inserted between two pages or the last task of
+		// a page is deleted
+		var difficulties []int
+		req := payload.ListTasksRequest{Provider: "Query Provider", Sort: "-priority,difficulty", Limit: 2}
+		for page := 0; ; page++ {
+			resp, err := repo.ListTasks(context.Background(), req)
+			require.NoError(t, err)
+			for _, task := range resp.Tasks {
+				difficulties = append(difficulties, task.Difficulty)
+			}
+			if page == 0 {
+				require.Equal(t, int64(5), resp.Total)
+				_, err := repo.CreateTask(context.Background(), payload.CreateTaskRequest{
+					ExternalID: 9000, Name: "Query Task", Duration: 1, Difficulty: 1, Priority: 1, Provider: "Query Provider",
+				})
+				require.NoError(t, err)
+				err = repo.DeleteTask(context.Background(), payload.DeleteTaskRequest{ID: resp.Tasks[len(resp.Tasks)-1].ID})
+				require.NoError(t, err)
+			}
+			if !resp.HasMore {
+				break
+			}
+			req.Cursor = resp.NextCursor
+		}
+		require.Equal(t, []int{1, 3, 5, 2, 4}, difficulties)
+
+		_, err = repo.ListTasks(context.Background(), payload.ListTasksRequest{Sort: "unknown"})
+		require.ErrorIs(t, err, repository.ErrInvalidQuery)
+		_, err = repo.ListTasks(context.Background(), payload.ListTasksRequest{Cursor: "not a cursor"})
+		require.ErrorIs(t, err, repository.ErrInvalidQuery)
+	})
+
+	t.Run("IterateTasks", func(t *testing.T) {
+		// More tasks than a batch holds
+		reqs := make([]payload.CreateTaskRequest, 0, 510)
+		for i := 0; i < 510; i++ {
+			reqs = append(reqs, payload.CreateTaskRequest{ExternalID: uint(9400 + i), Name: "Iterated Task", Duration: 1, Difficulty: 1, Provider: "Iterate Provider"})
+		}
+		results, err := repo.UpsertTasks(context.Background(), reqs)
+		require.NoError(t, err)
+		require.NoError(t, repo.DeleteTask(context.Background(), payload.DeleteTaskRequest{ID: results[0].ID}))
+
+		var batches int
+		var lastID uint
+		seen := map[uint]bool{}
+		err = repo.IterateTasks(context.Background(), func(tasks []payload.Task) error {
+			batches++
+			require.LessOrEqual(t, len(tasks), 500)
+			for _, task := range tasks {
+				require.Greater(t, task.ID, lastID)
+				lastID = task.ID
+				seen[task.ID] = true
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, batches, 2)
+		require.False(t, seen[results[0].ID])
+		for _, result := range results[1:] {
+			require.True(t, seen[result.ID])
+		}
+
+		// An error of the callback stops the iteration
+		stop := errors.New("stop")
+		calls := 0
+		err = repo.IterateTasks(context.Background(), func(tasks []payload.Task) error {
+			calls++
+			return stop
+		})
+		require.ErrorIs(t, err, stop)
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("SearchTasks", func(t *testing.T) {
+		for i, task := range []payload.CreateTaskRequest{
+			{Name: "Rotate database credentials", Description: "Move the billing service to short lived credentials"},
+			{Name: "Billing dashboard", Description: "Show invoices and payment failures"},
+			{Name: "Update onboarding docs", Description: "Mention the billing sandbox"},
+		} {
+			task.ExternalID = uint(9100 + i)
+			task.Duration = 3
+			task.Difficulty = 2
+			task.Provider = "Search Provider"
+			_, err := repo.CreateTask(context.Background(), task)
+			require.NoError(t, err)
+		}
+
+		resp, err := repo.SearchTasks(context.Background(), payload.SearchTasksRequest{Query: "billing"})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), resp.Total)
+		require.Len(t, resp.Results, 3)
+		// A match in the name ranks above matches in the description
+		require.Equal(t, "Billing dashboard", resp.Results[0].Task.Name)
+		require.Contains(t, resp.Results[0].Snippet, "<mark>")
+		require.GreaterOrEqual(t, resp.Results[0].Rank, resp.Results[1].Rank)
+
+		resp, err = repo.SearchTasks(context.Background(), payload.SearchTasksRequest{Query: "billing -invoices", Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), resp.Total)
+		require.Len(t, resp.Results, 1)
+
+		// Deleted tasks are not found
+		require.NoError(t, repo.DeleteTask(context.Background(), payload.DeleteTaskRequest{ID: resp.Results[0].Task.ID}))
+		resp, err = repo.SearchTasks(context.Background(), payload.SearchTasksRequest{Query: "billing -invoices"})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), resp.Total)
+	})
+
+	t.Run("DeveloperCRUD", func(t *testing.T) {
+		created, err := repo.CreateDeveloper(context.Background(), payload.CreateDeveloperRequest{
+			FirstName:       "New",
+			LastName:        "Developer",
+			Email:           "new.developer@example.com",
+			Capacity:        3,
+			PartTimePercent: 100,
+		})
+		require.NoError(t, err)
+		require.Greater(t, created.ID, uint(5))
+
+		_, err = repo.CreateDeveloper(context.Background(), payload.CreateDeveloperRequest{FirstName: "Copy", Email: "new.developer@example.com", Capacity: 1})
+		require.ErrorIs(t, err, repository.ErrAlreadyExists)
+		_, err = repo.UpdateDeveloper(context.Background(), payload.UpdateDeveloperRequest{ID: created.ID, FirstName: "New", Email: "dev1@example.com", Capacity: 3})
+		require.ErrorIs(t, err, repository.ErrAlreadyExists)
+
+		updated, err := repo.UpdateDeveloper(context.Background(), payload.UpdateDeveloperRequest{
+			ID:              created.ID,
+			FirstName:       "Renamed",
+			Email:           "new.developer@example.com",
+			Capacity:        4,
+			PartTimePercent: 50,
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Renamed", updated.FirstName)
+		require.Equal(t, 4, updated.Capacity)
+		require.Equal(t, 50, updated.PartTimePercent)
+
+		require.NoError(t, repo.DeleteDeveloper(context.Background(), payload.DeleteDeveloperRequest{ID: created.ID}))
+		_, err = repo.GetDeveloper(context.Background(), payload.GetDeveloperRequest{ID: created.ID})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+		require.ErrorIs(t, repo.DeleteDeveloper(context.Background(), payload.DeleteDeveloperRequest{ID: created.ID}), repository.ErrNotFound)
+
+		list, err := repo.ListDevelopers(context.Background(), payload.ListDevelopersRequest{})
+		require.NoError(t, err)
+		for _, developer := range list.Developers {
+			require.NotEqual(t, created.ID, developer.ID)
+		}
+
+		// The email of a deleted developer can be used again
+		_, err = repo.CreateDeveloper(context.Background(), payload.CreateDeveloperRequest{FirstName: "Again", Email: "new.developer@example.com", Capacity: 1})
+		require.NoError(t, err)
+
+		// Emails are unique regardless of case, also when created at once
+		_, err = repo.CreateDeveloper(context.Background(), payload.CreateDeveloperRequest{FirstName: "Upper", Email: "New.Developer@example.com", Capacity: 1})
+		require.ErrorIs(t, err, repository.ErrAlreadyExists)
+
+		errs := make(chan error, 5)
+		for i := 0; i < 5; i++ {
+			go func() {
+				_, err := repo.CreateDeveloper(context.Background(), payload.CreateDeveloperRequest{FirstName: "Racing", Email: "racing@example.com", Capacity: 1})
+				errs <- err
+			}()
+		}
+		succeeded := 0
+		for i := 0; i < 5; i++ {
+			if err := <-errs; err == nil {
+				succeeded++
+			} else {
+				require.ErrorIs(t, err, repository.ErrAlreadyExists)
+			}
+		}
+		require.Equal(t, 1, succeeded)
+	})
+
+	t.Run("DeleteDeveloper", func(t *testing.T) {
+		developer, err := repo.CreateDeveloper(context.Background(), payload.CreateDeveloperRequest{FirstName: "Leaving", Email: "leaving@example.com", Capacity: 2})
+		require.NoError(t, err)
+		task, err := repo.CreateTask(context.Background(), payload.CreateTaskRequest{ExternalID: 8801, Name: "Assigned", Duration: 2, Difficulty: 2, Provider: "delete-developer"})
+		require.NoError(t, err)
+		_, err = repo.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: task.ID, Status: payload.StatusInProgress, AssigneeID: &developer.ID})
+		require.NoError(t, err)
+		_, err = repo.CreateAbsence(context.Background(), payload.CreateAbsenceRequest{
+			DeveloperID: developer.ID,
+			StartDate:   time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+			EndDate:     time.Date(2026, 11, 6, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.DeleteDeveloper(context.Background(), payload.DeleteDeveloperRequest{ID: developer.ID}))
+
+		// Its tasks are unassigned and its absences deleted
+		unassigned, err := repo.GetTask(context.Background(), payload.GetTaskRequest{ID: task.ID})
+		require.NoError(t, err)
+		require.Nil(t, unassigned.AssigneeID)
+		absences, err := repo.ListAbsences(context.Background(), payload.ListAbsencesRequest{DeveloperID: developer.ID})
+		require.NoError(t, err)
+		require.Empty(t, absences.Absences)
+
+		events, err := repo.ListTaskEvents(context.Background(), payload.ListTaskEventsRequest{TaskID: task.ID})
+		require.NoError(t, err)
+		last := events.Events[len(events.Events)-1]
+		require.Equal(t, payload.EventUpdated, last.Type)
+		require.Contains(t, last.Changes, "assigneeId")
+	})
+
+	t.Run("TaskHistory", func(t *testing.T) {
+		task, err := repo.CreateTask(context.Background(), payload.CreateTaskRequest{
+			ExternalID: 9300, Name: "History Task", Duration: 2, Difficulty: 2, Provider: "History Provider",
+		})
+		require.NoError(t, err)
+
+		userCtx := repository.WithOrigin(context.Background(), repository.Origin{Source: repository.SourceUser, Actor: "jane"})
+		duration := 4
+		_, err = repo.PatchTask(userCtx, payload.PatchTaskRequest{ID: task.ID, Duration: &duration})
+		require.NoError(t, err)
+		// Saving the same values again is not a change
+		_, err = repo.PatchTask(userCtx, payload.PatchTaskRequest{ID: task.ID, Duration: &duration})
+		require.NoError(t, err)
+		_, err = repo.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: task.ID, Status: payload.StatusInProgress})
+		require.NoError(t, err)
+		require.NoError(t, repo.DeleteTask(context.Background(), payload.DeleteTaskRequest{ID: task.ID}))
+
+		history, err := repo.ListTaskEvents(context.Background(), payload.ListTaskEventsRequest{TaskID: task.ID})
+		require.NoError(t, err)
+		require.EqualValues(t, 4, history.Total)
+		require.Len(t, history.Events, 4)
+
+		types := make([]payload.TaskEventType, len(history.Events))
+		for i, event := range history.Events {
+			types[i] = event.Type
+		}
+		require.Equal(t, []payload.TaskEventType{
+			payload.EventCreated, payload.EventUpdated, payload.EventStatusChanged, payload.EventDeleted,
+		}, types)
+
+		require.Equal(t, "History Task", history.Events[0].Changes["name"].New)
+		require.Equal(t, repository.SourceAPI, history.Events[0].Source)
+
+		updated := history.Events[1]
+		require.Equal(t, repository.SourceUser, updated.Source)
+		require.Equal(t, "jane", updated.Actor)
+		require.Equal(t, map[string]payload.FieldChange{"duration": {Old: float64(2), New: float64(4)}}, updated.Changes)
+
+		require.Equal(t, payload.FieldChange{Old: string(payload.StatusBacklog), New: string(payload.StatusInProgress)}, history.Events[2].Changes["status"])
+
+		page, err := repo.ListTaskEvents(context.Background(), payload.ListTaskEventsRequest{TaskID: task.ID, Offset: 1, Limit: 1})
+		require.NoError(t, err)
+		require.EqualValues(t, 4, page.Total)
+		require.Len(t, page.Events, 1)
+		require.Equal(t, updated.ID, page.Events[0].ID)
+
+		_, err = repo.ListTaskEvents(context.Background(), payload.ListTaskEventsRequest{TaskID: 999999})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("Plans", func(t *testing.T) {
+		plan := payload.Plan{
+			Name:      "release",
+			Strategy:  "lpt",
+			Makespan:  12,
+			TaskCount: 1,
+			Options:   payload.ScheduleAssignmentRequest{Strategy: "lpt", Split: "weeks"},
+			Schedule: payload.ScheduleAssignmentResponse{
+				Strategy: "lpt",
+				Makespan: 12,
+				Assignments: []payload.Assignment{{
+					Week: 1,
+					DeveloperTasks: []payload.DeveloperTaskAssignment{{
+						Developer: payload.Developer{ID: 1},
+						Tasks:     []payload.Task{{ID: 1, Name: "Test Task"}},
+					}},
+				}},
+			},
+		}
+
+		first, err := repo.CreatePlan(context.Background(), plan)
+		require.NoError(t, err)
+		require.Equal(t, 1, first.Version)
+		second, err := repo.CreatePlan(context.Background(), plan)
+		require.NoError(t, err)
+		require.Equal(t, 2, second.Version)
+
+		got, err := repo.GetPlan(context.Background(), payload.GetPlanRequest{ID: first.ID})
+		require.NoError(t, err)
+		require.Equal(t, "weeks", got.Options.Split)
+		require.Len(t, got.Schedule.Assignments, 1)
+		require.Equal(t, uint(1), got.Schedule.Assignments[0].DeveloperTasks[0].Tasks[0].ID)
+
+		_, err = repo.GetPlan(context.Background(), payload.GetPlanRequest{ID: 999})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+
+		list, err := repo.ListPlans(context.Background(), payload.ListPlansRequest{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, list.Plans, 1)
+		require.Equal(t, second.ID, list.Plans[0].ID)
+		require.EqualValues(t, 2, list.Total)
+		list, err = repo.ListPlans(context.Background(), payload.ListPlansRequest{Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, list.Plans, 1)
+		require.Equal(t, first.ID, list.Plans[0].ID)
+
+		// Plans saved at the same time get distinct versions
+		created := make(chan payload.Plan, 3)
+		for i := 0; i < 3; i++ {
+			go func() {
+				saved, err := repo.CreatePlan(context.Background(), plan)
+				if err != nil {
+					saved.Version = -1
+				}
+				created <- saved
+			}()
+		}
+		var versions []int
+		for i := 0; i < 3; i++ {
+			versions = append(versions, (<-created).Version)
+		}
+		require.ElementsMatch(t, []int{3, 4, 5}, versions)
+	})
 }
@@ -0,0 +1,132 @@
+package postgres_repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/database/postgres/tables"
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultEventLimit is the number of events returned when a request has no limit.
+const defaultEventLimit = 100
+
+// taskJSONNames maps the fields of a task, named as their columns, to the
+// names used in the JSON payload and so in the recorded changes.
+var taskJSONNames = func() map[string]string {
+	names := map[string]string{}
+	t := reflect.TypeOf(payload.Task{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		names[field.Name] = strings.Split(field.Tag.Get("json"), ",")[0]
+	}
+	return names
+}()
+
+// taskChanges returns the old and new values of the given fields that differ
+// between two versions of a task.
+func taskChanges(old, new payload.Task, fields []string) map[string]payload.FieldChange {
+	changes := map[string]payload.FieldChange{}
+	oldValue, newValue := reflect.ValueOf(old), reflect.ValueOf(new)
+	for _, field := range fields {
+		before := oldValue.FieldByName(field).Interface()
+		after := newValue.FieldByName(field).Interface()
+		if !sameValue(before, after) {
+			changes[taskJSONNames[field]] = payload.FieldChange{Old: before, New: after}
+		}
+	}
+	return changes
+}
+
+// createdChanges returns the values a task was created with.
+func createdChanges(task payload.Task, fields []string) map[string]payload.FieldChange {
+	changes := map[string]payload.FieldChange{}
+	value := reflect.ValueOf(task)
+	for _, field := range fields {
+		changes[taskJSONNames[field]] = payload.FieldChange{New: value.FieldByName(field).Interface()}
+	}
+	return changes
+}
+
+// sameValue compares field values of tasks. Times are compared as instants
+// and empty lists of skills are the same, however they were stored.
+func sameValue(a, b interface{}) bool {
+	switch x := a.(type) {
+	case *time.Time:
+		y := b.(*time.Time)
+		if x == nil || y == nil {
+			return x == nil && y == nil
+		}
+		return x.Equal(*y)
+	case payload.Skills:
+		y := b.(payload.Skills)
+		if len(x) == 0 && len(y) == 0 {
+			return true
+		}
+		return reflect.DeepEqual(x, y)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// newTaskEvent builds an event of a task, made by the origin of ctx.
+func newTaskEvent(ctx context.Context, taskID uint, eventType payload.TaskEventType, changes map[string]payload.FieldChange) (tables.TaskEvent, error) {
+	origin := repository.OriginFrom(ctx)
+	event := tables.TaskEvent{TaskID: taskID, Type: string(eventType), Source: origin.Source, Actor: origin.Actor}
+	if len(changes) > 0 {
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return event, err
+		}
+		event.Changes = string(data)
+	}
+	return event, nil
+}
+
+func taskEventFromRow(row tables.TaskEvent) (payload.TaskEvent, error) {
+	event := payload.TaskEvent{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		Type:      payload.TaskEventType(row.Type),
+		Source:    row.Source,
+		Actor:     row.Actor,
+		CreatedAt: row.CreatedAt,
+	}
+	if row.Changes != "" {
+		if err := json.Unmarshal([]byte(row.Changes), &event.Changes); err != nil {
+			return event, fmt.Errorf("invalid changes of task event id=%v: %w", row.ID, err)
+		}
+	}
+	return event, nil
+}
+
+// lockTask reads a task that is not deleted, locking it until the end of the transaction.
+func lockTask(tx *gorm.DB, id uint) (payload.Task, error) {
+	var task payload.Task
+	err := tx.Model(&tables.Task{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(`"ID" = ? AND "IsDeleted" = ?`, id, false).
+		Take(&task).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return task, fmt.Errorf("task with id=%v: %w", id, repository.ErrNotFound)
+	}
+	return task, err
+}
+
+// sortedColumns returns the columns of an update in a stable order.
+func sortedColumns(columns map[string]interface{}) []string {
+	fields := make([]string, 0, len(columns))
+	for field := range columns {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
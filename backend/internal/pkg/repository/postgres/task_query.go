@@ -0,0 +1,285 @@
+package postgres_repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/database/postgres"
+	"github.com/mehmetali10/task-planner/internal/pkg/database/postgres/tables"
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+)
+
+const (
+	// defaultTaskLimit is the page size used when a list request has no limit.
+	defaultTaskLimit = 1000
+	// iterateBatchSize is the number of rows read at once when iterating over all
+	// tasks, dependencies or absences.
+	iterateBatchSize = 500
+	// defaultSearchLimit is the number of results returned when a search has no limit.
+	defaultSearchLimit = 20
+	// snippetOptions configures ts_headline, marking the matching words.
+	snippetOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2"
+)
+
+// taskSearchRow is a task found by a text search with its rank and snippet.
+type taskSearchRow struct {
+	payload.Task
+	Rank    float64
+	Snippet string
+}
+
+// taskSortField is a field tasks can be sorted by.
+type taskSortField struct {
+	// expr is the SQL expression sorted by. Nullable columns are coalesced so
+	// they can be compared when paginating with a cursor.
+	expr string
+	// cast is the SQL type of expr, the values of a cursor are read as.
+	cast string
+	// value returns the value of expr for a task as text.
+	value func(task payload.Task) string
+}
+
+// taskSortFields maps the sortable task fields, named as in the JSON payload,
+// to their SQL expressions.
+var taskSortFields = map[string]taskSortField{
+	"id":         {`"ID"`, "bigint", func(t payload.Task) string { return uintText(t.ID) }},
+	"externalId": {`"ExternalID"`, "bigint", func(t payload.Task) string { return uintText(t.ExternalID) }},
+	"name":       {`"Name"`, "text", func(t payload.Task) string { return t.Name }},
+	"duration":   {`"Duration"`, "bigint", func(t payload.Task) string { return strconv.Itoa(t.Duration) }},
+	"difficulty": {`"Difficulty"`, "bigint", func(t payload.Task) string { return strconv.Itoa(t.Difficulty) }},
+	"priority":   {`"Priority"`, "bigint", func(t payload.Task) string { return strconv.Itoa(t.Priority) }},
+	"status":     {`"Status"`, "text", func(t payload.Task) string { return string(t.Status) }},
+	"provider":   {`"Provider"`, "text", func(t payload.Task) string { return t.Provider }},
+	"dueDate":    {`COALESCE("DueDate", 'infinity')`, "timestamptz", func(t payload.Task) string { return timeText(t.DueDate, "infinity") }},
+	"createdAt":  {`COALESCE("CreatedAt", '-infinity')`, "timestamptz", func(t payload.Task) string { return timeText(t.CreatedAt, "-infinity") }},
+	"updatedAt":  {`COALESCE("UpdatedAt", '-infinity')`, "timestamptz", func(t payload.Task) string { return timeText(t.UpdatedAt, "-infinity") }},
+}
+
+func uintText(v uint) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+// timeText formats a time for Postgres, a missing time is the given one.
+func timeText(t *time.Time, missing string) string {
+	if t == nil {
+		return missing
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+type sortKey struct {
+	taskSortField
+	field string
+	desc  bool
+}
+
+// parseTaskSort parses a sort expression like "priority,-createdAt", where a
+// leading minus sorts descending. The ID is always added as the last key so
+// the order is total, which cursor pagination relies on.
+func parseTaskSort(sort string) ([]sortKey, error) {
+	var keys []sortKey
+	seen := map[string]bool{}
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+		sortField, ok := taskSortFields[field]
+		if !ok {
+			return nil, fmt.Errorf("unknown sort field %q: %w", field, repository.ErrInvalidQuery)
+		}
+		if seen[field] {
+			return nil, fmt.Errorf("sort field %q given twice: %w", field, repository.ErrInvalidQuery)
+		}
+		seen[field] = true
+		keys = append(keys, sortKey{taskSortField: sortField, field: field, desc: desc})
+	}
+	if !seen["id"] {
+		keys = append(keys, sortKey{taskSortField: taskSortFields["id"], field: "id"})
+	}
+	return keys, nil
+}
+
+func orderClauses(keys []sortKey) []string {
+	orders := make([]string, len(keys))
+	for i, key := range keys {
+		orders[i] = key.expr
+		if key.desc {
+			orders[i] += " DESC"
+		}
+	}
+	return orders
+}
+
+// sortText is the normalized sort expression of keys, e.g. "-priority,id".
+func sortText(keys []sortKey) string {
+	fields := make([]string, len(keys))
+	for i, key := range keys {
+		fields[i] = key.field
+		if key.desc {
+			fields[i] = "-" + fields[i]
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+// afterCondition selects the records sorted after the one with the given
+// sort values, as carried by a cursor. Records inserted, updated or deleted
+// meanwhile, the one of the cursor included, do not move the page.
+func afterCondition(keys []sortKey, values []string) *postgres.Condition {
+	value := func(key sortKey) string {
+		return fmt.Sprintf("CAST(? AS %s)", key.cast)
+	}
+
+	var terms []string
+	var args []any
+	for i, key := range keys {
+		var parts []string
+		for j, prev := range keys[:i] {
+			parts = append(parts, prev.expr+" = "+value(prev))
+			args = append(args, values[j])
+		}
+		op := ">"
+		if key.desc {
+			op = "<"
+		}
+		parts = append(parts, key.expr+" "+op+" "+value(key))
+		args = append(args, values[i])
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+	}
+	return &postgres.Condition{Query: "(" + strings.Join(terms, " OR ") + ")", Args: args}
+}
+
+// taskCursor is the content of a cursor, the sort values of the last task of
+// a page together with the sort they belong to.
+type taskCursor struct {
+	Sort   string   `json:"sort"`
+	Values []string `json:"values"`
+}
+
+// encodeCursor returns the cursor of the tasks sorted by keys after task.
+func encodeCursor(keys []sortKey, task payload.Task) string {
+	cursor := taskCursor{Sort: sortText(keys), Values: make([]string, len(keys))}
+	for i, key := range keys {
+		cursor.Values[i] = key.value(task)
+	}
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor returns the sort values of a cursor, which has to be made for
+// the same sort.
+func decodeCursor(keys []sortKey, text string) ([]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", repository.ErrInvalidQuery)
+	}
+	var cursor taskCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || len(cursor.Values) != len(keys) {
+		return nil, fmt.Errorf("malformed cursor: %w", repository.ErrInvalidQuery)
+	}
+	if cursor.Sort != sortText(keys) {
+		return nil, fmt.Errorf("cursor of sort %q used with sort %q: %w", cursor.Sort, sortText(keys), repository.ErrInvalidQuery)
+	}
+	return cursor.Values, nil
+}
+
+// taskFilters turns the filters of a list request into SQL conditions.
+func taskFilters(req payload.ListTasksRequest) []postgres.Condition {
+	filters := []postgres.Condition{{Query: `"IsDeleted" = ?`, Args: []any{false}}}
+	add := func(query string, arg any) {
+		filters = append(filters, postgres.Condition{Query: query, Args: []any{arg}})
+	}
+	if req.Provider != "" {
+		add(`"Provider" = ?`, req.Provider)
+	}
+	if len(req.Status) > 0 {
+		add(`"Status" IN ?`, req.Status)
+	}
+	if req.MinDifficulty > 0 {
+		add(`"Difficulty" >= ?`, req.MinDifficulty)
+	}
+	if req.MaxDifficulty > 0 {
+		add(`"Difficulty" <= ?`, req.MaxDifficulty)
+	}
+	if req.MinDuration > 0 {
+		add(`"Duration" >= ?`, req.MinDuration)
+	}
+	if req.MaxDuration > 0 {
+		add(`"Duration" <= ?`, req.MaxDuration)
+	}
+	if req.CreatedAfter != nil {
+		add(`"CreatedAt" > ?`, *req.CreatedAfter)
+	}
+	return filters
+}
+
+// providerColumns are the task columns a provider owns. They are written when
+// a task is created and updated when the provider changes them.
+var providerColumns = []string{"Name", "Description", "Duration", "Difficulty", "Priority", "DueDate", "Skills"}
+
+// taskUpsertRow is a task returned by the upsert query.
+type taskUpsertRow struct {
+	ID         uint
+	ExternalID uint
+	Provider   string
+	CreatedAt  *time.Time
+	Inserted   bool
+}
+
+// upsertTasksQuery builds a single statement inserting the tasks, or updating
+// the provider columns of existing tasks that are not deleted and differ.
+// The keys of the tasks have to be unique. Postgres sets xmax of a row only
+// when it was updated, which tells the inserted rows apart.
+func upsertTasksQuery(reqs []payload.CreateTaskRequest, now time.Time) (string, []any) {
+	columns := append([]string{"ExternalID", "Provider"}, providerColumns...)
+	columns = append(columns, "CreatedAt", "UpdatedAt")
+
+	values := make([]string, len(reqs))
+	args := make([]any, 0, len(reqs)*len(columns))
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	for i, req := range reqs {
+		values[i] = placeholders
+		args = append(args,
+			req.ExternalID, req.Provider,
+			req.Name, req.Description, req.Duration, req.Difficulty, req.Priority, req.DueDate, req.Skills,
+			now, now,
+		)
+	}
+
+	table := tables.Task{}.TableName()
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = `"` + column + `"`
+	}
+	updates := make([]string, 0, len(providerColumns)+1)
+	current := make([]string, len(providerColumns))
+	excluded := make([]string, len(providerColumns))
+	for i, column := range providerColumns {
+		updates = append(updates, fmt.Sprintf(`"%[1]s" = EXCLUDED."%[1]s"`, column))
+		current[i] = fmt.Sprintf(`%s."%s"`, table, column)
+		excluded[i] = fmt.Sprintf(`EXCLUDED."%s"`, column)
+	}
+	updates = append(updates, `"UpdatedAt" = EXCLUDED."UpdatedAt"`)
+
+	query := fmt.Sprintf(
+		`INSERT INTO %[1]s (%[2]s) VALUES %[3]s
+		ON CONFLICT ("ExternalID", "Provider") DO UPDATE SET %[4]s
+		WHERE %[1]s."IsDeleted" = false AND (%[5]s) IS DISTINCT FROM (%[6]s)
+		RETURNING "ID", "ExternalID", "Provider", "CreatedAt", (xmax = 0) AS "Inserted"`,
+		table,
+		strings.Join(quoted, ", "),
+		strings.Join(values, ", "),
+		strings.Join(updates, ", "),
+		strings.Join(current, ", "),
+		strings.Join(excluded, ", "),
+	)
+	return query, args
+}
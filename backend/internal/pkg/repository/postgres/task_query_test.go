@@ -0,0 +1,61 @@
+package postgres_repository
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTaskSort(t *testing.T) {
+	keys, err := parseTaskSort("-priority, dueDate")
+	require.NoError(t, err)
+	require.Equal(t, []string{`"Priority" DESC`, `COALESCE("DueDate", 'infinity')`, `"ID"`}, orderClauses(keys))
+
+	keys, err = parseTaskSort("-id")
+	require.NoError(t, err)
+	require.Equal(t, []string{`"ID" DESC`}, orderClauses(keys))
+
+	_, err = parseTaskSort("password")
+	require.ErrorIs(t, err, repository.ErrInvalidQuery)
+	_, err = parseTaskSort("name,-name")
+	require.ErrorIs(t, err, repository.ErrInvalidQuery)
+}
+
+func TestTaskCursor(t *testing.T) {
+	keys, err := parseTaskSort("-priority,dueDate")
+	require.NoError(t, err)
+	created := time.Date(2026, 10, 19, 9, 30, 0, 123456000, time.UTC)
+	task := payload.Task{ID: 7, Priority: 3, CreatedAt: &created}
+
+	cursor := encodeCursor(keys, task)
+	values, err := decodeCursor(keys, cursor)
+	require.NoError(t, err)
+	require.Equal(t, []string{"3", "infinity", "7"}, values)
+
+	keys, err = parseTaskSort("createdAt")
+	require.NoError(t, err)
+	values, err = decodeCursor(keys, encodeCursor(keys, task))
+	require.NoError(t, err)
+	require.Equal(t, []string{"2026-10-19T09:30:00.123456Z", "7"}, values)
+
+	// A cursor only continues the sort it was made for
+	_, err = decodeCursor(keys, cursor)
+	require.ErrorIs(t, err, repository.ErrInvalidQuery)
+	for _, cursor := range []string{"%%", "YWJj", base64.RawURLEncoding.EncodeToString([]byte(`{"sort":"createdAt,id","values":["7"]}`))} {
+		_, err := decodeCursor(keys, cursor)
+		require.ErrorIs(t, err, repository.ErrInvalidQuery, cursor)
+	}
+
+	keys, err = parseTaskSort("-priority")
+	require.NoError(t, err)
+	after := afterCondition(keys, []string{"3", "7"})
+	require.Equal(t,
+		`(("Priority" < CAST(? AS bigint)) OR `+
+			`("Priority" = CAST(? AS bigint) AND "ID" > CAST(? AS bigint)))`,
+		after.Query)
+	require.Equal(t, []any{"3", "3", "7"}, after.Args)
+}
@@ -50,11 +50,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/task": {
+            },
             "post": {
-                "description": "Create a new task",
+                "description": "Add a developer to the team, emails have to be unique",
                 "consumes": [
                     "application/json"
                 ],
@@ -62,9 +60,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "developer"
                 ],
-                "summary": "Create a task",
+                "summary": "Create a developer",
                 "parameters": [
                     {
                         "description": "Create Request",
@@ -72,15 +70,15 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/payload.CreateTaskRequest"
+                            "$ref": "#/definitions/payload.CreateDeveloperRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully created task",
+                        "description": "Created developer",
                         "schema": {
-                            "$ref": "#/definitions/payload.CreateTaskResponse"
+                            "$ref": "#/definitions/payload.Developer"
                         }
                     },
                     "400": {
@@ -89,6 +87,12 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "409": {
+                        "description": "Email already in use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -98,9 +102,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks": {
+        "/developers/{id}": {
             "get": {
-                "description": "Retrieve a list of tasks",
+                "description": "Retrieve a single developer",
                 "consumes": [
                     "application/json"
                 ],
@@ -108,28 +112,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "developer"
                 ],
-                "summary": "List tasks",
+                "summary": "Get a developer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Limit",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Developer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Developer",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Developer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Developer not found",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the details, capacity, availability and skills of a developer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "developer"
+                ],
+                "summary": "Update a developer",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
-                        "in": "query"
+                        "description": "Developer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.UpdateDeveloperRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of tasks",
+                        "description": "Updated developer",
                         "schema": {
-                            "$ref": "#/definitions/payload.ListTasksResponse"
+                            "$ref": "#/definitions/payload.Developer"
                         }
                     },
                     "400": {
@@ -138,6 +194,57 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "404": {
+                        "description": "Developer not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already in use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a developer from the team, no more tasks are scheduled for them. Their tasks are unassigned and their absences deleted",
+                "tags": [
+                    "developer"
+                ],
+                "summary": "Delete a developer",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Developer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Developer deleted"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Developer not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -147,9 +254,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/tasks/schedule": {
+        "/developers/{id}/absences": {
             "get": {
-                "description": "Automatically schedule assignments for tasks",
+                "description": "Retrieve the absences of a developer",
                 "consumes": [
                     "application/json"
                 ],
@@ -157,14 +264,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "task"
+                    "developer"
+                ],
+                "summary": "List absences",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Developer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Schedule assignments",
                 "responses": {
                     "200": {
-                        "description": "Scheduled assignments",
+                        "description": "List of absences",
                         "schema": {
-                            "$ref": "#/definitions/payload.ScheduleAssignmentResponse"
+                            "$ref": "#/definitions/payload.ListAbsencesResponse"
                         }
                     },
                     "500": {
@@ -174,175 +290,2523 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "payload.Assignment": {
-            "type": "object",
-            "properties": {
-                "developerTasks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/payload.DeveloperTaskAssignment"
+            },
+            "post": {
+                "description": "Record a vacation or other absence of a developer, the scheduler assigns no work on these days",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "developer"
+                ],
+                "summary": "Create an absence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Developer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.CreateAbsenceRequest"
+                        }
                     }
-                }
-            }
-        },
-        "payload.CreateTaskRequest": {
-            "type": "object",
-            "required": [
-                "difficulty",
-                "duration",
-                "externalId",
-                "name",
-                "provider"
-            ],
-            "properties": {
-                "difficulty": {
-                    "type": "integer",
-                    "maximum": 10,
-                    "minimum": 1
-                },
-                "duration": {
-                    "type": "integer",
-                    "maximum": 1000,
-                    "minimum": 1
-                },
-                "externalId": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
-                },
-                "provider": {
-                    "type": "string",
-                    "maxLength": 150,
-                    "minLength": 3
-                }
-            }
-        },
-        "payload.CreateTaskResponse": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "payload.Developer": {
-            "type": "object",
-            "properties": {
-                "capacity": {
-                    "type": "integer"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "firstName": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "lastName": {
-                    "type": "string"
-                },
-                "updatedAt": {
-                    "type": "string"
-                }
-            }
-        },
-        "payload.DeveloperTaskAssignment": {
-            "type": "object",
-            "properties": {
-                "developer": {
-                    "$ref": "#/definitions/payload.Developer"
-                },
-                "tasks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/payload.Task"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully created absence",
+                        "schema": {
+                            "$ref": "#/definitions/payload.CreateAbsenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Developer not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
-        "payload.ListDevelopersResponse": {
-            "type": "object",
-            "properties": {
-                "developers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/payload.Developer"
+        "/developers/{id}/absences/{absenceId}": {
+            "get": {
+                "description": "Retrieve a single absence of a developer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "developer"
+                ],
+                "summary": "Get an absence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Developer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Absence ID",
+                        "name": "absenceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Absence",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Absence"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Absence not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Change the dates or the reason of an absence",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "developer"
+                ],
+                "summary": "Update an absence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Developer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Absence ID",
+                        "name": "absenceId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.UpdateAbsenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated absence",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Absence"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Absence not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove an absence of a developer",
+                "tags": [
+                    "developer"
+                ],
+                "summary": "Delete an absence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Developer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Absence ID",
+                        "name": "absenceId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Absence deleted"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Absence not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/developers/{id}/skills": {
+            "put": {
+                "description": "Replace the skills of a developer, tasks requiring skills are only assigned to developers holding them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "developer"
+                ],
+                "summary": "Update developer skills",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Developer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.UpdateDeveloperSkillsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated developer",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Developer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Developer not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans": {
+            "get": {
+                "description": "Retrieve a page of the saved plans, newest first, without their schedules, with the number of saved plans",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plan"
+                ],
+                "summary": "List plans",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of plans",
+                        "schema": {
+                            "$ref": "#/definitions/payload.ListPlansResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Compute a schedule with the given options and save it as the next version of the named plan",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plan"
+                ],
+                "summary": "Save a plan",
+                "parameters": [
+                    {
+                        "description": "Create Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.CreatePlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved plan",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Plan"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans/{id}": {
+            "get": {
+                "description": "Retrieve a saved plan together with its options and schedule",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plan"
+                ],
+                "summary": "Get a plan",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Plan",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Plan"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Plan not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/plans/{id}/diff/{otherId}": {
+            "get": {
+                "description": "List the tasks added, removed or moved to other developers or weeks between two saved plans",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plan"
+                ],
+                "summary": "Compare two plans",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the plan to compare with",
+                        "name": "otherId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Differences",
+                        "schema": {
+                            "$ref": "#/definitions/payload.DiffPlansResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Plan not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/task": {
+            "post": {
+                "description": "Create a new task. When the provider already has a task with the same externalId, that task is updated instead and the status tells whether it changed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Create a task",
+                "parameters": [
+                    {
+                        "description": "Create Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.CreateTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully created or updated task",
+                        "schema": {
+                            "$ref": "#/definitions/payload.CreateTaskResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks": {
+            "get": {
+                "description": "Retrieve a page of tasks, filtered and sorted. Pages are positioned with an offset or, stable under concurrent inserts, with the cursor of the previous page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "List tasks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Limit, up to 1000 (default 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset, cannot be combined with a cursor",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned as nextCursor by the previous page, used with the same sort",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated fields, prefixed with - to sort descending, e.g. -priority,createdAt",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Provider",
+                        "name": "provider",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated statuses",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum difficulty",
+                        "name": "minDifficulty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum difficulty",
+                        "name": "maxDifficulty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum duration",
+                        "name": "minDuration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum duration",
+                        "name": "maxDuration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only tasks created after this RFC 3339 timestamp",
+                        "name": "createdAfter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of tasks",
+                        "schema": {
+                            "$ref": "#/definitions/payload.ListTasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/bulk": {
+            "post": {
+                "description": "Create many tasks at once, given as a JSON array or as newline delimited JSON (NDJSON) with one task per line. Each task is validated on its own and reported as created, duplicate, invalid or failed",
+                "consumes": [
+                    "application/json",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Create tasks in bulk",
+                "parameters": [
+                    {
+                        "description": "Tasks",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/payload.CreateTaskRequest"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result of each task",
+                        "schema": {
+                            "$ref": "#/definitions/payload.BulkCreateTasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body too large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/schedule": {
+            "get": {
+                "description": "Automatically schedule assignments for tasks",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Schedule assignments",
+                "parameters": [
+                    {
+                        "enum": [
+                            "greedy",
+                            "lpt",
+                            "best-fit",
+                            "branch-and-bound"
+                        ],
+                        "type": "string",
+                        "description": "Scheduling strategy",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include the day-by-day timeline of every developer",
+                        "name": "timeline",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Working hours per day",
+                        "name": "hoursPerDay",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Hour of the day work begins, from 0 to 23, e.g. 9",
+                        "name": "dayStartHour",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated work days, e.g. Mon,Tue,Wed,Thu,Fri",
+                        "name": "workDays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated public holidays, e.g. 2026-12-25,2027-01-01",
+                        "name": "holidays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the plan, e.g. 2026-10-19",
+                        "name": "startDate",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "hard",
+                            "soft"
+                        ],
+                        "type": "string",
+                        "description": "How required task skills are matched",
+                        "name": "skillMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Effort factor per missing skill in soft mode",
+                        "name": "skillPenalty",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "none",
+                            "weeks",
+                            "developers"
+                        ],
+                        "type": "string",
+                        "description": "How tasks that do not fit into a week are split",
+                        "name": "split",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Saved plan to reschedule from, keeping started work in place",
+                        "name": "basePlanId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated IDs of tasks in progress or done, kept where the base plan put them",
+                        "name": "frozenTaskIds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day up to which the weeks of the base plan have elapsed, e.g. 2026-10-19, defaults to today",
+                        "name": "asOf",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scheduled assignments",
+                        "schema": {
+                            "$ref": "#/definitions/payload.ScheduleAssignmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/search": {
+            "get": {
+                "description": "Full-text search over task names and descriptions, best matches first. The query supports quoted phrases, OR and -word to exclude a word",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Search tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit, up to 100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching tasks",
+                        "schema": {
+                            "$ref": "#/definitions/payload.SearchTasksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}": {
+            "get": {
+                "description": "Retrieve a single task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Get a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Task",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the name, estimate, priority, due date and skills of a task",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Update a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.UpdateTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated task",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a task and its dependencies, the task is no longer listed or scheduled",
+                "tags": [
+                    "task"
+                ],
+                "summary": "Delete a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Task deleted"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Change some fields of a task, fields missing from the request are kept. A null dueDate clears the due date",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Patch a task",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Patch Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.PatchTaskRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated task",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/dependencies": {
+            "get": {
+                "description": "Retrieve the tasks a task depends on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "List task dependencies",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of dependencies",
+                        "schema": {
+                            "$ref": "#/definitions/payload.ListTaskDependenciesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Declare that a task cannot start before another task is finished",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Add a task dependency",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the blocked task",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.CreateTaskDependencyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully created dependency",
+                        "schema": {
+                            "$ref": "#/definitions/payload.CreateTaskDependencyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or dependency cycle",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Dependency already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/dependencies/{dependsOnId}": {
+            "delete": {
+                "description": "Let a task start without waiting for another task",
+                "tags": [
+                    "task"
+                ],
+                "summary": "Remove a task dependency",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the blocked task",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the task it depends on",
+                        "name": "dependsOnId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Dependency deleted"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Dependency not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/history": {
+            "get": {
+                "description": "Retrieve the changes of a task oldest first, with the old and new values of the changed fields and where each change came from. Send an X-User header with changes to record the user making them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Task history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Limit, up to 1000 (default 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "History of the task",
+                        "schema": {
+                            "$ref": "#/definitions/payload.ListTaskEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tasks/{id}/status": {
+            "patch": {
+                "description": "Move a task through its lifecycle, report its progress and assign it to a developer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "task"
+                ],
+                "summary": "Update task status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payload.UpdateTaskStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated task",
+                        "schema": {
+                            "$ref": "#/definitions/payload.Task"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Task or developer not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Status transition not allowed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "payload.Absence": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "developerId": {
+                    "type": "integer"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "payload.Assignment": {
+            "type": "object",
+            "properties": {
+                "developerTasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.DeveloperTaskAssignment"
+                    }
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "week": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.BulkCreateTasksResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "type": "integer"
+                },
+                "duplicates": {
+                    "type": "integer"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.TaskImportResult"
+                    }
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.CreateAbsenceRequest": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "startDate"
+            ],
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "startDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "payload.CreateAbsenceResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.CreateDeveloperRequest": {
+            "type": "object",
+            "required": [
+                "capacity",
+                "email",
+                "firstName"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "firstName": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "lastName": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "partTimePercent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "skills": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "weeklyHours": {
+                    "type": "number",
+                    "maximum": 168,
+                    "minimum": 0
+                }
+            }
+        },
+        "payload.CreatePlanRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "options": {
+                    "$ref": "#/definitions/payload.ScheduleAssignmentRequest"
+                }
+            }
+        },
+        "payload.CreateTaskDependencyRequest": {
+            "type": "object",
+            "required": [
+                "dependsOnId"
+            ],
+            "properties": {
+                "dependsOnId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.CreateTaskDependencyResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.CreateTaskRequest": {
+            "type": "object",
+            "required": [
+                "difficulty",
+                "duration",
+                "externalId",
+                "name",
+                "provider"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "difficulty": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "dueDate": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "externalId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0
+                },
+                "provider": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 3
+                },
+                "skills": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "payload.CreateTaskResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/payload.TaskImportStatus"
+                }
+            }
+        },
+        "payload.Developer": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "partTimePercent": {
+                    "type": "integer"
+                },
+                "skills": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "weeklyHours": {
+                    "type": "number"
+                }
+            }
+        },
+        "payload.DeveloperTaskAssignment": {
+            "type": "object",
+            "properties": {
+                "chunks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.TaskChunk"
+                    }
+                },
+                "developer": {
+                    "$ref": "#/definitions/payload.Developer"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.Task"
+                    }
+                }
+            }
+        },
+        "payload.DeveloperTimeline": {
+            "type": "object",
+            "properties": {
+                "developer": {
+                    "$ref": "#/definitions/payload.Developer"
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.TimelineSlot"
+                    }
+                }
+            }
+        },
+        "payload.DiffPlansResponse": {
+            "type": "object",
+            "properties": {
+                "added": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.TaskChange"
+                    }
+                },
+                "finishDelta": {
+                    "type": "integer"
+                },
+                "from": {
+                    "$ref": "#/definitions/payload.PlanSummary"
+                },
+                "makespanDelta": {
+                    "type": "number"
+                },
+                "moved": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.TaskChange"
+                    }
+                },
+                "removed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.TaskChange"
+                    }
+                },
+                "to": {
+                    "$ref": "#/definitions/payload.PlanSummary"
+                },
+                "unchanged": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.FieldChange": {
+            "type": "object",
+            "properties": {
+                "new": {},
+                "old": {}
+            }
+        },
+        "payload.LateTask": {
+            "type": "object",
+            "properties": {
+                "daysLate": {
+                    "type": "integer"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/payload.Task"
+                }
+            }
+        },
+        "payload.ListAbsencesResponse": {
+            "type": "object",
+            "properties": {
+                "absences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.Absence"
+                    }
+                }
+            }
+        },
+        "payload.ListDevelopersResponse": {
+            "type": "object",
+            "properties": {
+                "developers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.Developer"
+                    }
+                }
+            }
+        },
+        "payload.ListPlansResponse": {
+            "type": "object",
+            "properties": {
+                "plans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.PlanSummary"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.ListTaskDependenciesResponse": {
+            "type": "object",
+            "properties": {
+                "dependencies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.TaskDependency"
+                    }
+                }
+            }
+        },
+        "payload.ListTaskEventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.TaskEvent"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.ListTasksResponse": {
+            "type": "object",
+            "properties": {
+                "hasMore": {
+                    "type": "boolean"
+                },
+                "nextCursor": {
+                    "type": "string"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.Task"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.PatchTaskRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "difficulty": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "dueDate": {
+                    "type": "string",
+                    "format": "date-time",
+                    "x-nullable": true
+                },
+                "duration": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0
+                },
+                "skills": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "payload.Plan": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "finishDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "makespan": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "$ref": "#/definitions/payload.ScheduleAssignmentRequest"
+                },
+                "schedule": {
+                    "$ref": "#/definitions/payload.ScheduleAssignmentResponse"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "taskCount": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.PlanSummary": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "finishDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "makespan": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "taskCount": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.Reschedule": {
+            "type": "object",
+            "properties": {
+                "basePlanId": {
+                    "type": "integer"
+                },
+                "elapsedWeeks": {
+                    "type": "integer"
+                },
+                "frozenTasks": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "movedTasks": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "payload.ScheduleAssignmentRequest": {
+            "type": "object",
+            "properties": {
+                "asOf": {
+                    "type": "string"
+                },
+                "basePlanId": {
+                    "type": "integer"
+                },
+                "dayStartHour": {
+                    "type": "number",
+                    "maximum": 23,
+                    "minimum": 0
+                },
+                "frozenTaskIds": {
+                    "type": "array",
+                    "maxItems": 10000,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "hoursPerDay": {
+                    "type": "number",
+                    "maximum": 24,
+                    "minimum": 0
+                },
+                "skillMatch": {
+                    "type": "string",
+                    "enum": [
+                        "hard",
+                        "soft"
+                    ]
+                },
+                "skillPenalty": {
+                    "type": "number",
+                    "minimum": 1
+                },
+                "split": {
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "weeks",
+                        "developers"
+                    ]
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "timeline": {
+                    "type": "boolean"
+                },
+                "workDays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "payload.ScheduleAssignmentResponse": {
+            "type": "object",
+            "properties": {
+                "assignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.Assignment"
+                    }
+                },
+                "consideredTasks": {
+                    "type": "integer"
+                },
+                "criticalPath": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.Task"
+                    }
+                },
+                "criticalPathHours": {
+                    "type": "number"
+                },
+                "finishDate": {
+                    "type": "string"
+                },
+                "lateTasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.LateTask"
+                    }
+                },
+                "makespan": {
+                    "type": "number"
+                },
+                "minWeek": {
+                    "type": "integer"
+                },
+                "reschedule": {
+                    "$ref": "#/definitions/payload.Reschedule"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.DeveloperTimeline"
+                    }
+                },
+                "totalElapsedWorkHour": {
+                    "type": "integer"
+                },
+                "totalWorkDay": {
+                    "type": "integer"
+                },
+                "unassignable": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payload.UnassignableTask"
                     }
                 }
             }
         },
-        "payload.ListTasksResponse": {
+        "payload.SearchTasksResponse": {
             "type": "object",
             "properties": {
-                "tasks": {
+                "results": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/payload.Task"
+                        "$ref": "#/definitions/payload.TaskSearchResult"
                     }
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "payload.ScheduleAssignmentResponse": {
+        "payload.Task": {
             "type": "object",
             "properties": {
-                "assignments": {
+                "assigneeId": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "difficulty": {
+                    "type": "integer"
+                },
+                "dueDate": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "externalId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percentComplete": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "skills": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/payload.Assignment"
+                        "type": "string"
                     }
                 },
-                "minWeek": {
+                "status": {
+                    "$ref": "#/definitions/payload.TaskStatus"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "payload.TaskChange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "$ref": "#/definitions/payload.TaskPlacement"
+                },
+                "task": {
+                    "$ref": "#/definitions/payload.Task"
+                },
+                "to": {
+                    "$ref": "#/definitions/payload.TaskPlacement"
+                }
+            }
+        },
+        "payload.TaskChunk": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "number"
+                },
+                "part": {
                     "type": "integer"
                 },
-                "totalElapsedWorkHour": {
+                "parts": {
                     "type": "integer"
                 },
-                "totalWorkDay": {
+                "taskId": {
                     "type": "integer"
                 }
             }
         },
-        "payload.Task": {
+        "payload.TaskDependency": {
             "type": "object",
             "properties": {
                 "createdAt": {
                     "type": "string"
                 },
-                "difficulty": {
+                "dependsOnId": {
                     "type": "integer"
                 },
-                "duration": {
+                "id": {
                     "type": "integer"
                 },
-                "externalId": {
+                "taskId": {
                     "type": "integer"
+                }
+            }
+        },
+        "payload.TaskEvent": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/payload.FieldChange"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "source": {
+                    "type": "string"
+                },
+                "taskId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/payload.TaskEventType"
+                }
+            }
+        },
+        "payload.TaskEventType": {
+            "type": "string",
+            "enum": [
+                "created",
+                "updated",
+                "status_changed",
+                "deleted"
+            ],
+            "x-enum-varnames": [
+                "EventCreated",
+                "EventUpdated",
+                "EventStatusChanged",
+                "EventDeleted"
+            ]
+        },
+        "payload.TaskImportResult": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string"
                 },
+                "externalId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
                 "provider": {
                     "type": "string"
                 },
-                "updatedAt": {
+                "status": {
+                    "$ref": "#/definitions/payload.TaskImportStatus"
+                }
+            }
+        },
+        "payload.TaskImportStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "updated",
+                "unchanged",
+                "deleted",
+                "duplicate",
+                "invalid",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImportCreated",
+                "ImportUpdated",
+                "ImportUnchanged",
+                "ImportDeleted",
+                "ImportDuplicate",
+                "ImportInvalid",
+                "ImportFailed"
+            ]
+        },
+        "payload.TaskPlacement": {
+            "type": "object",
+            "properties": {
+                "developerIds": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "endWeek": {
+                    "type": "integer"
+                },
+                "startWeek": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.TaskSearchResult": {
+            "type": "object",
+            "properties": {
+                "rank": {
+                    "type": "number"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/payload.Task"
+                }
+            }
+        },
+        "payload.TaskStatus": {
+            "type": "string",
+            "enum": [
+                "backlog",
+                "scheduled",
+                "in_progress",
+                "blocked",
+                "done",
+                "cancelled"
+            ],
+            "x-enum-varnames": [
+                "StatusBacklog",
+                "StatusScheduled",
+                "StatusInProgress",
+                "StatusBlocked",
+                "StatusDone",
+                "StatusCancelled"
+            ]
+        },
+        "payload.TimelineSlot": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number"
+                },
+                "part": {
+                    "type": "integer"
+                },
+                "parts": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/payload.Task"
+                },
+                "week": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payload.UnassignableTask": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "task": {
+                    "$ref": "#/definitions/payload.Task"
+                }
+            }
+        },
+        "payload.UpdateAbsenceRequest": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "startDate"
+            ],
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "startDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "payload.UpdateDeveloperRequest": {
+            "type": "object",
+            "required": [
+                "capacity",
+                "email",
+                "firstName"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 150
+                },
+                "firstName": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "lastName": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "partTimePercent": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "skills": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "weeklyHours": {
+                    "type": "number",
+                    "maximum": 168,
+                    "minimum": 0
+                }
+            }
+        },
+        "payload.UpdateDeveloperSkillsRequest": {
+            "type": "object",
+            "properties": {
+                "skills": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "payload.UpdateTaskRequest": {
+            "type": "object",
+            "required": [
+                "difficulty",
+                "duration",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 5000
+                },
+                "difficulty": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1
+                },
+                "dueDate": {
                     "type": "string"
+                },
+                "duration": {
+                    "type": "integer",
+                    "maximum": 1000,
+                    "minimum": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0
+                },
+                "skills": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "payload.UpdateTaskStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "assigneeId": {
+                    "type": "integer"
+                },
+                "percentComplete": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "status": {
+                    "enum": [
+                        "backlog",
+                        "scheduled",
+                        "in_progress",
+                        "blocked",
+                        "done",
+                        "cancelled"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/payload.TaskStatus"
+                        }
+                    ]
                 }
             }
         }
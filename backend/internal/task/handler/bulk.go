@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"unicode"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+const (
+	// maxBulkTasks limits the number of tasks of a bulk import.
+	maxBulkTasks = 10000
+	// maxBulkBodySize limits the size of a bulk import request body.
+	maxBulkBodySize = 32 << 20
+	// maxBulkLineSize limits the size of a single NDJSON line.
+	maxBulkLineSize = 1 << 20
+)
+
+// bulkItem is a task of a bulk import, or the reason it could not be decoded.
+type bulkItem struct {
+	task payload.CreateTaskRequest
+	err  error
+}
+
+// decodeBulkTasks reads the tasks of a bulk import, given either as a JSON
+// array or as newline delimited JSON with one task per line. A task that
+// cannot be decoded is returned with its error, so it does not fail the others.
+func decodeBulkTasks(body io.Reader) ([]bulkItem, error) {
+	reader := bufio.NewReader(body)
+	first, err := peekNonSpace(reader)
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var items []bulkItem
+	if first == '[' {
+		var raws []json.RawMessage
+		if err := json.NewDecoder(reader).Decode(&raws); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %w", err)
+		}
+		if len(raws) > maxBulkTasks {
+			return nil, fmt.Errorf("too many tasks, at most %d are allowed", maxBulkTasks)
+		}
+		for _, raw := range raws {
+			items = append(items, decodeBulkItem(raw))
+		}
+		return items, nil
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxBulkLineSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if len(items) == maxBulkTasks {
+			return nil, fmt.Errorf("too many tasks, at most %d are allowed", maxBulkTasks)
+		}
+		items = append(items, decodeBulkItem(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid NDJSON: %w", err)
+	}
+	return items, nil
+}
+
+func decodeBulkItem(raw []byte) bulkItem {
+	var item bulkItem
+	if err := json.Unmarshal(raw, &item.task); err != nil {
+		item.err = fmt.Errorf("invalid JSON: %w", err)
+	}
+	return item
+}
+
+// peekNonSpace returns the first byte that is not white space, without consuming it.
+func peekNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+		if !unicode.IsSpace(rune(b[0])) {
+			return b[0], nil
+		}
+		if _, err := reader.ReadByte(); err != nil {
+			return 0, err
+		}
+	}
+}
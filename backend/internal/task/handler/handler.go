@@ -2,19 +2,49 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
 	"github.com/mehmetali10/task-planner/internal/task/service"
 	"github.com/mehmetali10/task-planner/pkg/validate"
 )
 
 type Handler interface {
 	CreateTask() http.HandlerFunc
+	BulkCreateTasks() http.HandlerFunc
 	ListTasks() http.HandlerFunc
+	SearchTasks() http.HandlerFunc
+	GetTask() http.HandlerFunc
+	UpdateTask() http.HandlerFunc
+	PatchTask() http.HandlerFunc
+	DeleteTask() http.HandlerFunc
+	ListTaskEvents() http.HandlerFunc
+	UpdateTaskStatus() http.HandlerFunc
+	CreateTaskDependency() http.HandlerFunc
+	ListTaskDependencies() http.HandlerFunc
+	DeleteTaskDependency() http.HandlerFunc
 	ScheduleAssignments() http.HandlerFunc
 	ListDevelopers() http.HandlerFunc
+	CreateDeveloper() http.HandlerFunc
+	GetDeveloper() http.HandlerFunc
+	UpdateDeveloper() http.HandlerFunc
+	DeleteDeveloper() http.HandlerFunc
+	UpdateDeveloperSkills() http.HandlerFunc
+	CreateAbsence() http.HandlerFunc
+	GetAbsence() http.HandlerFunc
+	ListAbsences() http.HandlerFunc
+	UpdateAbsence() http.HandlerFunc
+	DeleteAbsence() http.HandlerFunc
+	CreatePlan() http.HandlerFunc
+	GetPlan() http.HandlerFunc
+	ListPlans() http.HandlerFunc
+	DiffPlans() http.HandlerFunc
 	Metrics() http.HandlerFunc
 }
 
@@ -30,12 +60,12 @@ func NewHandler(service service.Service) Handler {
 
 // CreateTaskHandler godoc
 // @Summary Create a task
-// @Description Create a new task
+// @Description Create a new task. When the provider already has a task with the same externalId, that task is updated instead and the status tells whether it changed
 // @Tags task
 // @Accept json
 // @Produce json
 // @Param request body payload.CreateTaskRequest true "Create Request"
-// @Success 200 {object} payload.CreateTaskResponse "Successfully created task"
+// @Success 200 {object} payload.CreateTaskResponse "Successfully created or updated task"
 // @Failure 400 {string} string "Invalid request"
 // @Failure 500 {string} string "Internal server error"
 // @Router /task [post]
@@ -63,26 +93,117 @@ func (h *handler) CreateTask() http.HandlerFunc {
 	}, "/task")
 }
 
+// BulkCreateTasksHandler godoc
+// @Summary Create tasks in bulk
+// @Description Create many tasks at once, given as a JSON array or as newline delimited JSON (NDJSON) with one task per line. Each task is validated on its own and reported as created, duplicate, invalid or failed
+// @Tags task
+// @Accept json
+// @Accept application/x-ndjson
+// @Produce json
+// @Param request body []payload.CreateTaskRequest true "Tasks"
+// @Success 200 {object} payload.BulkCreateTasksResponse "Result of each task"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 413 {string} string "Request body too large"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/bulk [post]
+func (h *handler) BulkCreateTasks() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		items, err := decodeBulkTasks(http.MaxBytesReader(w, r.Body, maxBulkBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Invalid tasks are reported right away, the others are created
+		// and their results put back at the position of the task.
+		results := make([]payload.TaskImportResult, len(items))
+		var tasks []payload.CreateTaskRequest
+		var indexes []int
+		for i, item := range items {
+			results[i] = payload.TaskImportResult{Index: i, ExternalID: item.task.ExternalID, Provider: item.task.Provider}
+			if item.err == nil {
+				item.err = validate.Request(item.task)
+			}
+			if item.err != nil {
+				results[i].Status = payload.ImportInvalid
+				results[i].Error = item.err.Error()
+				continue
+			}
+			tasks = append(tasks, item.task)
+			indexes = append(indexes, i)
+		}
+
+		created, err := h.service.CreateTasks(r.Context(), tasks)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+		for _, result := range created.Results {
+			result.Index = indexes[result.Index]
+			results[result.Index] = result
+		}
+
+		resp := payload.BulkCreateTasksResponse{Results: make([]payload.TaskImportResult, 0, len(results))}
+		for _, result := range results {
+			resp.Add(result)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/tasks/bulk")
+}
+
 // ListTasksHandler godoc
 // @Summary List tasks
-// @Description Retrieve a list of tasks
+// @Description Retrieve a page of tasks, filtered and sorted. Pages are positioned with an offset or, stable under concurrent inserts, with the cursor of the previous page
 // @Tags task
 // @Accept json
 // @Produce json
-// @Param limit query int false "Limit"
-// @Param offset query int false "Offset"
+// @Param limit query int false "Limit, up to 1000 (default 1000)"
+// @Param offset query int false "Offset, cannot be combined with a cursor"
+// @Param cursor query string false "Cursor returned as nextCursor by the previous page, used with the same sort"
+// @Param sort query string false "Comma separated fields, prefixed with - to sort descending, e.g. -priority,createdAt"
+// @Param provider query string false "Provider"
+// @Param status query string false "Comma separated statuses"
+// @Param minDifficulty query int false "Minimum difficulty"
+// @Param maxDifficulty query int false "Maximum difficulty"
+// @Param minDuration query int false "Minimum duration"
+// @Param maxDuration query int false "Maximum duration"
+// @Param createdAfter query string false "Only tasks created after this RFC 3339 timestamp"
 // @Success 200 {object} payload.ListTasksResponse "List of tasks"
 // @Failure 400 {string} string "Invalid request"
 // @Failure 500 {string} string "Internal server error"
 // @Router /tasks [get]
 func (h *handler) ListTasks() http.HandlerFunc {
 	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		limit := r.URL.Query().Get("limit")
-		offset := r.URL.Query().Get("offset")
+		query := r.URL.Query()
 
 		req := payload.ListTasksRequest{
-			Limit:  strToInt(limit),
-			Offset: strToInt(offset),
+			Limit:         strToInt(query.Get("limit")),
+			Offset:        strToInt(query.Get("offset")),
+			Cursor:        query.Get("cursor"),
+			Sort:          query.Get("sort"),
+			Provider:      query.Get("provider"),
+			MinDifficulty: strToInt(query.Get("minDifficulty")),
+			MaxDifficulty: strToInt(query.Get("maxDifficulty")),
+			MinDuration:   strToInt(query.Get("minDuration")),
+			MaxDuration:   strToInt(query.Get("maxDuration")),
+		}
+		for _, status := range strToList(query.Get("status")) {
+			req.Status = append(req.Status, payload.TaskStatus(strings.TrimSpace(status)))
+		}
+		if createdAfter := query.Get("createdAfter"); createdAfter != "" {
+			t, err := time.Parse(time.RFC3339, createdAfter)
+			if err != nil {
+				http.Error(w, "createdAfter must be an RFC 3339 timestamp", http.StatusBadRequest)
+				return
+			}
+			req.CreatedAfter = &t
 		}
 
 		if err := validate.Request(req); err != nil {
@@ -92,7 +213,7 @@ func (h *handler) ListTasks() http.HandlerFunc {
 
 		resp, err := h.service.ListTasks(r.Context(), req)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), errorStatus(err))
 			return
 		}
 
@@ -101,25 +222,410 @@ func (h *handler) ListTasks() http.HandlerFunc {
 	}, "/tasks")
 }
 
+// SearchTasksHandler godoc
+// @Summary Search tasks
+// @Description Full-text search over task names and descriptions, best matches first. The query supports quoted phrases, OR and -word to exclude a word
+// @Tags task
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Limit, up to 100 (default 20)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} payload.SearchTasksResponse "Matching tasks"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/search [get]
+func (h *handler) SearchTasks() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		req := payload.SearchTasksRequest{
+			Query:  strings.TrimSpace(query.Get("q")),
+			Limit:  strToInt(query.Get("limit")),
+			Offset: strToInt(query.Get("offset")),
+		}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.SearchTasks(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/tasks/search")
+}
+
+// GetTaskHandler godoc
+// @Summary Get a task
+// @Description Retrieve a single task
+// @Tags task
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} payload.Task "Task"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Task not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/{id} [get]
+func (h *handler) GetTask() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.GetTaskRequest{ID: pathID(r, "id")}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.GetTask(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/tasks/{id}")
+}
+
+// UpdateTaskHandler godoc
+// @Summary Update a task
+// @Description Replace the name, estimate, priority, due date and skills of a task
+// @Tags task
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param request body payload.UpdateTaskRequest true "Update Request"
+// @Success 200 {object} payload.Task "Updated task"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Task not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/{id} [put]
+func (h *handler) UpdateTask() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.UpdateTaskRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.ID = pathID(r, "id")
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.UpdateTask(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/tasks/{id}")
+}
+
+// PatchTaskHandler godoc
+// @Summary Patch a task
+// @Description Change some fields of a task, fields missing from the request are kept. A null dueDate clears the due date
+// @Tags task
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param request body payload.PatchTaskRequest true "Patch Request"
+// @Success 200 {object} payload.Task "Updated task"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Task not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/{id} [patch]
+func (h *handler) PatchTask() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.PatchTaskRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.ID = pathID(r, "id")
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.PatchTask(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/tasks/{id}")
+}
+
+// DeleteTaskHandler godoc
+// @Summary Delete a task
+// @Description Remove a task and its dependencies, the task is no longer listed or scheduled
+// @Tags task
+// @Param id path int true "Task ID"
+// @Success 204 "Task deleted"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Task not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/{id} [delete]
+func (h *handler) DeleteTask() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.DeleteTaskRequest{ID: pathID(r, "id")}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := h.service.DeleteTask(r.Context(), req); err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}, "/tasks/{id}")
+}
+
+// ListTaskEventsHandler godoc
+// @Summary Task history
+// @Description Retrieve the changes of a task oldest first, with the old and new values of the changed fields and where each change came from. Send an X-User header with changes to record the user making them
+// @Tags task
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param limit query int false "Limit, up to 1000 (default 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} payload.ListTaskEventsResponse "History of the task"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Task not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/{id}/history [get]
+func (h *handler) ListTaskEvents() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.ListTaskEventsRequest{
+			TaskID: pathID(r, "id"),
+			Limit:  strToInt(r.URL.Query().Get("limit")),
+			Offset: strToInt(r.URL.Query().Get("offset")),
+		}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.ListTaskEvents(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/tasks/{id}/history")
+}
+
+// UpdateTaskStatusHandler godoc
+// @Summary Update task status
+// @Description Move a task through its lifecycle, report its progress and assign it to a developer
+// @Tags task
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Param request body payload.UpdateTaskStatusRequest true "Status Request"
+// @Success 200 {object} payload.Task "Updated task"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Task or developer not found"
+// @Failure 409 {string} string "Status transition not allowed"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/{id}/status [patch]
+func (h *handler) UpdateTaskStatus() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.UpdateTaskStatusRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.ID = pathID(r, "id")
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.UpdateTaskStatus(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/tasks/{id}/status")
+}
+
+// CreateTaskDependencyHandler godoc
+// @Summary Add a task dependency
+// @Description Declare that a task cannot start before another task is finished
+// @Tags task
+// @Accept json
+// @Produce json
+// @Param id path int true "ID of the blocked task"
+// @Param request body payload.CreateTaskDependencyRequest true "Create Request"
+// @Success 200 {object} payload.CreateTaskDependencyResponse "Successfully created dependency"
+// @Failure 400 {string} string "Invalid request or dependency cycle"
+// @Failure 404 {string} string "Task not found"
+// @Failure 409 {string} string "Dependency already exists"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/{id}/dependencies [post]
+func (h *handler) CreateTaskDependency() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.CreateTaskDependencyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.TaskID = pathID(r, "id")
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.CreateTaskDependency(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/tasks/{id}/dependencies")
+}
+
+// ListTaskDependenciesHandler godoc
+// @Summary List task dependencies
+// @Description Retrieve the tasks a task depends on
+// @Tags task
+// @Accept json
+// @Produce json
+// @Param id path int true "Task ID"
+// @Success 200 {object} payload.ListTaskDependenciesResponse "List of dependencies"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/{id}/dependencies [get]
+func (h *handler) ListTaskDependencies() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.ListTaskDependenciesRequest{
+			TaskID: pathID(r, "id"),
+		}
+
+		resp, err := h.service.ListTaskDependencies(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/tasks/{id}/dependencies")
+}
+
+// DeleteTaskDependencyHandler godoc
+// @Summary Remove a task dependency
+// @Description Let a task start without waiting for another task
+// @Tags task
+// @Param id path int true "ID of the blocked task"
+// @Param dependsOnId path int true "ID of the task it depends on"
+// @Success 204 "Dependency deleted"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Dependency not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /tasks/{id}/dependencies/{dependsOnId} [delete]
+func (h *handler) DeleteTaskDependency() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.DeleteTaskDependencyRequest{
+			TaskID:      pathID(r, "id"),
+			DependsOnID: pathID(r, "dependsOnId"),
+		}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := h.service.DeleteTaskDependency(r.Context(), req); err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}, "/tasks/{id}/dependencies/{dependsOnId}")
+}
+
 // ScheduleAssignmentsHandler godoc
 // @Summary Schedule assignments
 // @Description Automatically schedule assignments for tasks
 // @Tags task
 // @Accept json
 // @Produce json
+// @Param strategy query string false "Scheduling strategy" Enums(greedy, lpt, best-fit, branch-and-bound)
+// @Param timeline query bool false "Include the day-by-day timeline of every developer"
+// @Param hoursPerDay query number false "Working hours per day"
+// @Param dayStartHour query number false "Hour of the day work begins, from 0 to 23, e.g. 9"
+// @Param workDays query string false "Comma separated work days, e.g. Mon,Tue,Wed,Thu,Fri"
+// @Param holidays query string false "Comma separated public holidays, e.g. 2026-12-25,2027-01-01"
+// @Param startDate query string false "First day of the plan, e.g. 2026-10-19"
+// @Param skillMatch query string false "How required task skills are matched" Enums(hard, soft)
+// @Param skillPenalty query number false "Effort factor per missing skill in soft mode"
+// @Param split query string false "How tasks that do not fit into a week are split" Enums(none, weeks, developers)
+// @Param basePlanId query int false "Saved plan to reschedule from, keeping started work in place"
+// @Param frozenTaskIds query string false "Comma separated IDs of tasks in progress or done, kept where the base plan put them"
+// @Param asOf query string false "Day up to which the weeks of the base plan have elapsed, e.g. 2026-10-19, defaults to today"
 // @Success 200 {object} payload.ScheduleAssignmentResponse "Scheduled assignments"
+// @Failure 400 {string} string "Invalid request"
 // @Failure 500 {string} string "Internal server error"
 // @Router /tasks/schedule [get]
 func (h *handler) ScheduleAssignments() http.HandlerFunc {
 	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
-		req, err := h.service.ScheduleAssignments(r.Context(), payload.ScheduleAssignmentRequest{})
+		query := r.URL.Query()
+		req := payload.ScheduleAssignmentRequest{
+			Strategy:      query.Get("strategy"),
+			Timeline:      strToBool(query.Get("timeline")),
+			HoursPerDay:   strToFloat(query.Get("hoursPerDay")),
+			DayStartHour:  strToOptionalFloat(query.Get("dayStartHour")),
+			WorkDays:      strToList(query.Get("workDays")),
+			Holidays:      strToList(query.Get("holidays")),
+			StartDate:     query.Get("startDate"),
+			SkillMatch:    query.Get("skillMatch"),
+			SkillPenalty:  strToFloat(query.Get("skillPenalty")),
+			Split:         query.Get("split"),
+			BasePlanID:    uint(strToInt(query.Get("basePlanId"))),
+			FrozenTaskIDs: strToIDs(query.Get("frozenTaskIds")),
+			AsOf:          query.Get("asOf"),
+		}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.ScheduleAssignments(r.Context(), req)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			http.Error(w, err.Error(), errorStatus(err))
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(req)
+		json.NewEncoder(w).Encode(resp)
 	}, "/tasks/schedule")
 }
 
@@ -145,10 +651,561 @@ func (h *handler) ListDevelopers() http.HandlerFunc {
 	}, "/developers")
 }
 
-func strToInt(s string) int {
-	i, err := strconv.Atoi(s)
-	if err != nil {
-		return 0
-	}
-	return i
+// CreateDeveloperHandler godoc
+// @Summary Create a developer
+// @Description Add a developer to the team, emails have to be unique
+// @Tags developer
+// @Accept json
+// @Produce json
+// @Param request body payload.CreateDeveloperRequest true "Create Request"
+// @Success 200 {object} payload.Developer "Created developer"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 409 {string} string "Email already in use"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers [post]
+func (h *handler) CreateDeveloper() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.CreateDeveloperRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.CreateDeveloper(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/developers")
+}
+
+// GetDeveloperHandler godoc
+// @Summary Get a developer
+// @Description Retrieve a single developer
+// @Tags developer
+// @Accept json
+// @Produce json
+// @Param id path int true "Developer ID"
+// @Success 200 {object} payload.Developer "Developer"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Developer not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers/{id} [get]
+func (h *handler) GetDeveloper() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.GetDeveloperRequest{ID: pathID(r, "id")}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.GetDeveloper(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/developers/{id}")
+}
+
+// UpdateDeveloperHandler godoc
+// @Summary Update a developer
+// @Description Replace the details, capacity, availability and skills of a developer
+// @Tags developer
+// @Accept json
+// @Produce json
+// @Param id path int true "Developer ID"
+// @Param request body payload.UpdateDeveloperRequest true "Update Request"
+// @Success 200 {object} payload.Developer "Updated developer"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Developer not found"
+// @Failure 409 {string} string "Email already in use"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers/{id} [put]
+func (h *handler) UpdateDeveloper() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.UpdateDeveloperRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.ID = pathID(r, "id")
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.UpdateDeveloper(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/developers/{id}")
+}
+
+// DeleteDeveloperHandler godoc
+// @Summary Delete a developer
+// @Description Remove a developer from the team, no more tasks are scheduled for them. Their tasks are unassigned and their absences deleted
+// @Tags developer
+// @Param id path int true "Developer ID"
+// @Success 204 "Developer deleted"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Developer not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers/{id} [delete]
+func (h *handler) DeleteDeveloper() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.DeleteDeveloperRequest{ID: pathID(r, "id")}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := h.service.DeleteDeveloper(r.Context(), req); err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}, "/developers/{id}")
+}
+
+// UpdateDeveloperSkillsHandler godoc
+// @Summary Update developer skills
+// @Description Replace the skills of a developer, tasks requiring skills are only assigned to developers holding them
+// @Tags developer
+// @Accept json
+// @Produce json
+// @Param id path int true "Developer ID"
+// @Param request body payload.UpdateDeveloperSkillsRequest true "Update Request"
+// @Success 200 {object} payload.Developer "Updated developer"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Developer not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers/{id}/skills [put]
+func (h *handler) UpdateDeveloperSkills() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.UpdateDeveloperSkillsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.ID = pathID(r, "id")
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.UpdateDeveloperSkills(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/developers/{id}/skills")
+}
+
+// CreateAbsenceHandler godoc
+// @Summary Create an absence
+// @Description Record a vacation or other absence of a developer, the scheduler assigns no work on these days
+// @Tags developer
+// @Accept json
+// @Produce json
+// @Param id path int true "Developer ID"
+// @Param request body payload.CreateAbsenceRequest true "Create Request"
+// @Success 200 {object} payload.CreateAbsenceResponse "Successfully created absence"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Developer not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers/{id}/absences [post]
+func (h *handler) CreateAbsence() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.CreateAbsenceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.DeveloperID = pathID(r, "id")
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.CreateAbsence(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/developers/{id}/absences")
+}
+
+// GetAbsenceHandler godoc
+// @Summary Get an absence
+// @Description Retrieve a single absence of a developer
+// @Tags developer
+// @Accept json
+// @Produce json
+// @Param id path int true "Developer ID"
+// @Param absenceId path int true "Absence ID"
+// @Success 200 {object} payload.Absence "Absence"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Absence not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers/{id}/absences/{absenceId} [get]
+func (h *handler) GetAbsence() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.GetAbsenceRequest{
+			ID:          pathID(r, "absenceId"),
+			DeveloperID: pathID(r, "id"),
+		}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.GetAbsence(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/developers/{id}/absences/{absenceId}")
+}
+
+// ListAbsencesHandler godoc
+// @Summary List absences
+// @Description Retrieve the absences of a developer
+// @Tags developer
+// @Accept json
+// @Produce json
+// @Param id path int true "Developer ID"
+// @Success 200 {object} payload.ListAbsencesResponse "List of absences"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers/{id}/absences [get]
+func (h *handler) ListAbsences() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.ListAbsencesRequest{
+			DeveloperID: pathID(r, "id"),
+		}
+
+		resp, err := h.service.ListAbsences(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/developers/{id}/absences")
+}
+
+// UpdateAbsenceHandler godoc
+// @Summary Update an absence
+// @Description Change the dates or the reason of an absence
+// @Tags developer
+// @Accept json
+// @Produce json
+// @Param id path int true "Developer ID"
+// @Param absenceId path int true "Absence ID"
+// @Param request body payload.UpdateAbsenceRequest true "Update Request"
+// @Success 200 {object} payload.Absence "Updated absence"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Absence not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers/{id}/absences/{absenceId} [put]
+func (h *handler) UpdateAbsence() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.UpdateAbsenceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req.ID = pathID(r, "absenceId")
+		req.DeveloperID = pathID(r, "id")
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.UpdateAbsence(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/developers/{id}/absences/{absenceId}")
+}
+
+// DeleteAbsenceHandler godoc
+// @Summary Delete an absence
+// @Description Remove an absence of a developer
+// @Tags developer
+// @Param id path int true "Developer ID"
+// @Param absenceId path int true "Absence ID"
+// @Success 204 "Absence deleted"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Absence not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /developers/{id}/absences/{absenceId} [delete]
+func (h *handler) DeleteAbsence() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.DeleteAbsenceRequest{
+			ID:          pathID(r, "absenceId"),
+			DeveloperID: pathID(r, "id"),
+		}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := h.service.DeleteAbsence(r.Context(), req); err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}, "/developers/{id}/absences/{absenceId}")
+}
+
+// CreatePlanHandler godoc
+// @Summary Save a plan
+// @Description Compute a schedule with the given options and save it as the next version of the named plan
+// @Tags plan
+// @Accept json
+// @Produce json
+// @Param request body payload.CreatePlanRequest true "Create Request"
+// @Success 200 {object} payload.Plan "Saved plan"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 500 {string} string "Internal server error"
+// @Router /plans [post]
+func (h *handler) CreatePlan() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		var req payload.CreatePlanRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.CreatePlan(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/plans")
+}
+
+// GetPlanHandler godoc
+// @Summary Get a plan
+// @Description Retrieve a saved plan together with its options and schedule
+// @Tags plan
+// @Accept json
+// @Produce json
+// @Param id path int true "Plan ID"
+// @Success 200 {object} payload.Plan "Plan"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Plan not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /plans/{id} [get]
+func (h *handler) GetPlan() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.GetPlanRequest{ID: pathID(r, "id")}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.GetPlan(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/plans/{id}")
+}
+
+// ListPlansHandler godoc
+// @Summary List plans
+// @Description Retrieve a page of the saved plans, newest first, without their schedules, with the number of saved plans
+// @Tags plan
+// @Accept json
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} payload.ListPlansResponse "List of plans"
+// @Failure 500 {string} string "Internal server error"
+// @Router /plans [get]
+func (h *handler) ListPlans() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.ListPlansRequest{
+			Limit:  strToInt(r.URL.Query().Get("limit")),
+			Offset: strToInt(r.URL.Query().Get("offset")),
+		}
+
+		resp, err := h.service.ListPlans(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/plans")
+}
+
+// DiffPlansHandler godoc
+// @Summary Compare two plans
+// @Description List the tasks added, removed or moved to other developers or weeks between two saved plans
+// @Tags plan
+// @Accept json
+// @Produce json
+// @Param id path int true "Plan ID"
+// @Param otherId path int true "ID of the plan to compare with"
+// @Success 200 {object} payload.DiffPlansResponse "Differences"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "Plan not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /plans/{id}/diff/{otherId} [get]
+func (h *handler) DiffPlans() http.HandlerFunc {
+	return metricMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		req := payload.DiffPlansRequest{
+			ID:      pathID(r, "id"),
+			OtherID: pathID(r, "otherId"),
+		}
+
+		if err := validate.Request(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := h.service.DiffPlans(r.Context(), req)
+		if err != nil {
+			http.Error(w, err.Error(), errorStatus(err))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}, "/plans/{id}/diff/{otherId}")
+}
+
+func strToInt(s string) int {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+// pathID reads a numeric path variable, returning zero when it is missing or invalid.
+func pathID(r *http.Request, name string) uint {
+	id, err := strconv.ParseUint(mux.Vars(r)[name], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return uint(id)
+}
+
+func strToFloat(s string) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// strToOptionalFloat parses a number that may be missing, returning nil when it
+// is missing or invalid.
+func strToOptionalFloat(s string) *float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+func strToBool(s string) bool {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false
+	}
+	return b
+}
+
+func strToList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// strToIDs parses a comma separated list of IDs, skipping invalid entries.
+func strToIDs(s string) []uint {
+	var ids []uint
+	for _, item := range strToList(s) {
+		if id, err := strconv.ParseUint(strings.TrimSpace(item), 10, 64); err == nil {
+			ids = append(ids, uint(id))
+		}
+	}
+	return ids
+}
+
+// errorStatus maps errors returned by the service to HTTP status codes.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUnknownStrategy),
+		errors.Is(err, service.ErrInvalidCalendar),
+		errors.Is(err, service.ErrDependencyCycle),
+		errors.Is(err, service.ErrInvalidSplitMode),
+		errors.Is(err, service.ErrInvalidReschedule),
+		errors.Is(err, repository.ErrInvalidQuery):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, repository.ErrAlreadyExists),
+		errors.Is(err, service.ErrInvalidTransition):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
 }
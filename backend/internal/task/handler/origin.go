@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+)
+
+// userHeader names the user making a request, recorded in the history of the tasks they change.
+const userHeader = "X-User"
+
+// OriginMiddleware records where the changes of a request come from: the user
+// named by the X-User header, or the API when there is none.
+func OriginMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := repository.Origin{Source: repository.SourceAPI}
+		if user := strings.TrimSpace(r.Header.Get(userHeader)); user != "" {
+			origin = repository.Origin{Source: repository.SourceUser, Actor: user}
+		}
+		next.ServeHTTP(w, r.WithContext(repository.WithOrigin(r.Context(), origin)))
+	})
+}
@@ -71,12 +71,40 @@ func (s *Server) Stop() {
 }
 
 func (s *Server) setUpRoutes() {
+	s.router.Use(handler.OriginMiddleware)
+
 	s.router.HandleFunc("/task", s.handler.CreateTask()).Methods(http.MethodPost)
 	s.router.HandleFunc("/tasks", s.handler.ListTasks()).Methods(http.MethodGet)
+	s.router.HandleFunc("/tasks/search", s.handler.SearchTasks()).Methods(http.MethodGet)
+	s.router.HandleFunc("/tasks/bulk", s.handler.BulkCreateTasks()).Methods(http.MethodPost)
+	s.router.HandleFunc("/tasks/{id:[0-9]+}", s.handler.GetTask()).Methods(http.MethodGet)
+	s.router.HandleFunc("/tasks/{id:[0-9]+}", s.handler.UpdateTask()).Methods(http.MethodPut)
+	s.router.HandleFunc("/tasks/{id:[0-9]+}", s.handler.PatchTask()).Methods(http.MethodPatch)
+	s.router.HandleFunc("/tasks/{id:[0-9]+}", s.handler.DeleteTask()).Methods(http.MethodDelete)
+	s.router.HandleFunc("/tasks/{id:[0-9]+}/status", s.handler.UpdateTaskStatus()).Methods(http.MethodPatch)
+	s.router.HandleFunc("/tasks/{id:[0-9]+}/history", s.handler.ListTaskEvents()).Methods(http.MethodGet)
+	s.router.HandleFunc("/tasks/{id:[0-9]+}/dependencies", s.handler.CreateTaskDependency()).Methods(http.MethodPost)
+	s.router.HandleFunc("/tasks/{id:[0-9]+}/dependencies", s.handler.ListTaskDependencies()).Methods(http.MethodGet)
+	s.router.HandleFunc("/tasks/{id:[0-9]+}/dependencies/{dependsOnId:[0-9]+}", s.handler.DeleteTaskDependency()).Methods(http.MethodDelete)
 
 	s.router.HandleFunc("/tasks/schedule", s.handler.ScheduleAssignments()).Methods(http.MethodGet)
 
 	s.router.HandleFunc("/developers", s.handler.ListDevelopers()).Methods(http.MethodGet)
+	s.router.HandleFunc("/developers", s.handler.CreateDeveloper()).Methods(http.MethodPost)
+	s.router.HandleFunc("/developers/{id:[0-9]+}", s.handler.GetDeveloper()).Methods(http.MethodGet)
+	s.router.HandleFunc("/developers/{id:[0-9]+}", s.handler.UpdateDeveloper()).Methods(http.MethodPut)
+	s.router.HandleFunc("/developers/{id:[0-9]+}", s.handler.DeleteDeveloper()).Methods(http.MethodDelete)
+	s.router.HandleFunc("/developers/{id:[0-9]+}/skills", s.handler.UpdateDeveloperSkills()).Methods(http.MethodPut)
+	s.router.HandleFunc("/developers/{id:[0-9]+}/absences", s.handler.CreateAbsence()).Methods(http.MethodPost)
+	s.router.HandleFunc("/developers/{id:[0-9]+}/absences", s.handler.ListAbsences()).Methods(http.MethodGet)
+	s.router.HandleFunc("/developers/{id:[0-9]+}/absences/{absenceId:[0-9]+}", s.handler.GetAbsence()).Methods(http.MethodGet)
+	s.router.HandleFunc("/developers/{id:[0-9]+}/absences/{absenceId:[0-9]+}", s.handler.UpdateAbsence()).Methods(http.MethodPut)
+	s.router.HandleFunc("/developers/{id:[0-9]+}/absences/{absenceId:[0-9]+}", s.handler.DeleteAbsence()).Methods(http.MethodDelete)
+
+	s.router.HandleFunc("/plans", s.handler.CreatePlan()).Methods(http.MethodPost)
+	s.router.HandleFunc("/plans", s.handler.ListPlans()).Methods(http.MethodGet)
+	s.router.HandleFunc("/plans/{id:[0-9]+}", s.handler.GetPlan()).Methods(http.MethodGet)
+	s.router.HandleFunc("/plans/{id:[0-9]+}/diff/{otherId:[0-9]+}", s.handler.DiffPlans()).Methods(http.MethodGet)
 	s.router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
 	s.router.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
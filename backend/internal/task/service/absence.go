@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// CreateAbsence implements Service.
+func (s *service) CreateAbsence(ctx context.Context, req payload.CreateAbsenceRequest) (payload.CreateAbsenceResponse, error) {
+	s.logger.Trace("Creating absence developerId=%v", req.DeveloperID)
+	resp, err := s.repository.CreateAbsence(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to create absence developerId=%v: error=%v", req.DeveloperID, err)
+		return resp, err
+	}
+	s.logger.Trace("Absence created successfully id=%v, developerId=%v", resp.ID, req.DeveloperID)
+	return resp, nil
+}
+
+// GetAbsence implements Service.
+func (s *service) GetAbsence(ctx context.Context, req payload.GetAbsenceRequest) (payload.Absence, error) {
+	s.logger.Trace("Getting absence id=%v, developerId=%v", req.ID, req.DeveloperID)
+	resp, err := s.repository.GetAbsence(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to get absence id=%v, developerId=%v: error=%v", req.ID, req.DeveloperID, err)
+		return resp, err
+	}
+	return resp, nil
+}
+
+// ListAbsences implements Service.
+func (s *service) ListAbsences(ctx context.Context, req payload.ListAbsencesRequest) (payload.ListAbsencesResponse, error) {
+	s.logger.Trace("Listing absences developerId=%v", req.DeveloperID)
+	resp, err := s.repository.ListAbsences(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to list absences developerId=%v: error=%v", req.DeveloperID, err)
+		return resp, err
+	}
+	s.logger.Trace("Absences listed successfully developerId=%v", req.DeveloperID)
+	return resp, nil
+}
+
+// UpdateAbsence implements Service.
+func (s *service) UpdateAbsence(ctx context.Context, req payload.UpdateAbsenceRequest) (payload.Absence, error) {
+	s.logger.Trace("Updating absence id=%v, developerId=%v", req.ID, req.DeveloperID)
+	resp, err := s.repository.UpdateAbsence(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to update absence id=%v, developerId=%v: error=%v", req.ID, req.DeveloperID, err)
+		return resp, err
+	}
+	s.logger.Trace("Absence updated successfully id=%v, developerId=%v", req.ID, req.DeveloperID)
+	return resp, nil
+}
+
+// DeleteAbsence implements Service.
+func (s *service) DeleteAbsence(ctx context.Context, req payload.DeleteAbsenceRequest) error {
+	s.logger.Trace("Deleting absence id=%v, developerId=%v", req.ID, req.DeveloperID)
+	if err := s.repository.DeleteAbsence(ctx, req); err != nil {
+		s.logger.Error("Failed to delete absence id=%v, developerId=%v: error=%v", req.ID, req.DeveloperID, err)
+		return err
+	}
+	s.logger.Trace("Absence deleted successfully id=%v, developerId=%v", req.ID, req.DeveloperID)
+	return nil
+}
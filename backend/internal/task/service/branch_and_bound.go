@@ -0,0 +1,167 @@
+package service
+
+import (
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// branchAndBoundScheduler searches every developer/week placement for the
+// plan with the lowest makespan. The search is exponential, so it is only
+// used for up to maxTasks tasks and stops after maxNodes visited nodes,
+// keeping the best plan found so far. Larger inputs, and inputs with tasks
+// that have to be split, are handed to LPT.
+type branchAndBoundScheduler struct {
+	maxTasks int
+	maxNodes int
+}
+
+type placement struct {
+	dev  int
+	week int
+}
+
+func (branchAndBoundScheduler) Name() string { return "branch-and-bound" }
+
+func (s branchAndBoundScheduler) Schedule(tt *Timetable, tasks []payload.Task) {
+	if len(tasks) > s.maxTasks {
+		lptScheduler{}.Schedule(tt, tasks)
+		return
+	}
+
+	// Placing the hardest tasks first makes the bound prune early, as far as
+	// the dependencies allow
+	sortByDifficulty(tasks)
+
+	// Tasks no developer can take are left out, like the other strategies do
+	placeable := tt.Order(tt.Placeable(tasks))
+	for _, task := range placeable {
+		for dev := range tt.Developers() {
+			if tt.NeedsSplit(task, dev) {
+				lptScheduler{}.Schedule(tt, tasks)
+				return
+			}
+		}
+	}
+
+	// The LPT plan is the incumbent the search has to beat
+	seed := tt.clone()
+	lptScheduler{}.Schedule(seed, append([]payload.Task(nil), placeable...))
+
+	search := &bnbSearch{
+		tt:       tt.clone(),
+		tasks:    placeable,
+		maxNodes: s.maxNodes,
+		best:     seed.Makespan(),
+		current:  make([]placement, len(placeable)),
+	}
+	search.run(0)
+
+	if search.bestPlan == nil {
+		*tt = *seed
+		return
+	}
+	for i, p := range search.bestPlan {
+		tt.Assign(placeable[i], p.dev, p.week)
+	}
+}
+
+type bnbSearch struct {
+	tt       *Timetable
+	tasks    []payload.Task
+	nodes    int
+	maxNodes int
+	best     float64
+	bestPlan []placement
+	current  []placement
+}
+
+func (b *bnbSearch) run(i int) {
+	if b.nodes >= b.maxNodes {
+		return
+	}
+	b.nodes++
+
+	if b.tt.Makespan() >= b.best {
+		return
+	}
+	if i == len(b.tasks) {
+		b.best = b.tt.Makespan()
+		b.bestPlan = append(b.bestPlan[:0], b.current...)
+		return
+	}
+
+	task := b.tasks[i]
+	_, assigned := b.tt.assignee(task)
+	for dev := range b.tt.Developers() {
+		if !b.tt.CanTake(task, dev) || (!assigned && b.isSymmetric(dev)) {
+			continue
+		}
+		for week := 0; week <= b.tt.Weeks(); week++ {
+			if !b.tt.Fits(task, dev, week) || b.tt.FinishWith(task, dev, week) >= b.best {
+				continue
+			}
+			b.tt.Assign(task, dev, week)
+			b.current[i] = placement{dev: dev, week: week}
+			b.run(i + 1)
+			b.tt.unassign(dev, week)
+		}
+	}
+}
+
+// isSymmetric reports whether an earlier developer with the same capacity has
+// no work yet either, in which case trying dev would only repeat that branch.
+func (b *bnbSearch) isSymmetric(dev int) bool {
+	if b.tt.lastWeek(dev) >= 0 {
+		return false
+	}
+	developers := b.tt.Developers()
+	for other := 0; other < dev; other++ {
+		if developers[other].Capacity == developers[dev].Capacity && b.tt.lastWeek(other) < 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// clone returns a deep copy of the timetable.
+func (tt *Timetable) clone() *Timetable {
+	c := *tt
+	c.days = append([][][]workDay(nil), tt.days...)
+	c.finish = make(map[uint]float64, len(tt.finish))
+	for id, hour := range tt.finish {
+		c.finish[id] = hour
+	}
+	c.weeks = make([]timetableWeek, len(tt.weeks))
+	for i, week := range tt.weeks {
+		c.weeks[i] = timetableWeek{
+			load:     append([]float64(nil), week.load...),
+			bookings: make([][]booking, len(week.bookings)),
+		}
+		for dev, bookings := range week.bookings {
+			c.weeks[i].bookings[dev] = append([]booking(nil), bookings...)
+		}
+	}
+	return &c
+}
+
+// unassign removes the task booked last for a developer in a week.
+func (tt *Timetable) unassign(dev, week int) {
+	bookings := tt.weeks[week].bookings[dev]
+	last := bookings[len(bookings)-1]
+	tt.weeks[week].bookings[dev] = bookings[:len(bookings)-1]
+	tt.weeks[week].load[dev] = 0
+	if len(bookings) > 1 {
+		previous := bookings[len(bookings)-2]
+		tt.weeks[week].load[dev] = previous.start + previous.effort
+	}
+	delete(tt.finish, last.task.ID)
+
+	// Drop trailing weeks that became empty
+	for len(tt.weeks) > 0 {
+		for _, bookings := range tt.weeks[len(tt.weeks)-1].bookings {
+			if len(bookings) > 0 {
+				return
+			}
+		}
+		tt.weeks = tt.weeks[:len(tt.weeks)-1]
+	}
+}
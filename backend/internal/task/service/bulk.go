@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// bulkBatchSize is the number of tasks created in one transaction.
+const bulkBatchSize = 500
+
+// CreateTasks implements Service. The tasks are saved in batches, each in its
+// own transaction, creating new tasks and updating the existing ones of their
+// providers. A batch that fails does not stop the later ones, its tasks are
+// reported as failed instead.
+func (s *service) CreateTasks(ctx context.Context, tasks []payload.CreateTaskRequest) (payload.BulkCreateTasksResponse, error) {
+	s.logger.Trace("Creating tasks in bulk count=%v", len(tasks))
+	resp := payload.BulkCreateTasksResponse{Results: make([]payload.TaskImportResult, 0, len(tasks))}
+
+	for start := 0; start < len(tasks); start += bulkBatchSize {
+		batch := append([]payload.CreateTaskRequest(nil), tasks[start:min(start+bulkBatchSize, len(tasks))]...)
+		for i := range batch {
+			batch[i].Skills = payload.NewSkills(batch[i].Skills...)
+		}
+
+		results, err := s.repository.UpsertTasks(ctx, batch)
+		if err != nil {
+			s.logger.Error("Failed to save tasks %v to %v: error=%v", start, start+len(batch)-1, err)
+			for i, task := range batch {
+				resp.Add(payload.TaskImportResult{
+					Index:      start + i,
+					ExternalID: task.ExternalID,
+					Provider:   task.Provider,
+					Status:     payload.ImportFailed,
+					Error:      err.Error(),
+				})
+			}
+			continue
+		}
+		for _, result := range results {
+			result.Index += start
+			resp.Add(result)
+		}
+	}
+
+	s.logger.Trace(
+		"Tasks saved in bulk created=%v, updated=%v, unchanged=%v, failed=%v",
+		resp.Created,
+		resp.Updated,
+		resp.Unchanged,
+		resp.Failed,
+	)
+	return resp, nil
+}
@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/stretchr/testify/require"
+)
+
+// UpsertTasks fails batches holding a task of the "unavailable" provider.
+func (f *fakeRepo) UpsertTasks(ctx context.Context, reqs []payload.CreateTaskRequest) ([]payload.TaskImportResult, error) {
+	for _, req := range reqs {
+		if req.Provider == "unavailable" {
+			return nil, errors.New("provider table is locked")
+		}
+	}
+
+	results := make([]payload.TaskImportResult, len(reqs))
+	for i, req := range reqs {
+		results[i] = payload.TaskImportResult{Index: i, ExternalID: req.ExternalID, Provider: req.Provider, Status: payload.ImportCreated}
+		for _, task := range f.tasks {
+			if task.ExternalID == req.ExternalID && task.Provider == req.Provider {
+				results[i].Status = payload.ImportUnchanged
+			}
+		}
+		if results[i].Status == payload.ImportCreated {
+			results[i].ID = uint(len(f.tasks) + 1)
+			f.tasks = append(f.tasks, payload.Task{ID: results[i].ID, ExternalID: req.ExternalID, Provider: req.Provider, Skills: req.Skills})
+		}
+	}
+	return results, nil
+}
+
+func TestCreateTasks(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := newScheduleService(t, repo)
+
+	// Enough tasks for three batches, the second one failing and the
+	// last one saving a task of the first again
+	var tasks []payload.CreateTaskRequest
+	for i := 0; i < 1100; i++ {
+		tasks = append(tasks, payload.CreateTaskRequest{ExternalID: uint(i + 1), Provider: "mock", Skills: payload.Skills{"Backend "}})
+	}
+	tasks[600].Provider = "unavailable"
+	tasks[1099].ExternalID = 1
+
+	resp, err := svc.CreateTasks(context.Background(), tasks)
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1100)
+	require.Equal(t, 599, resp.Created)
+	require.Equal(t, 500, resp.Failed)
+	require.Equal(t, 1, resp.Unchanged)
+
+	for i, result := range resp.Results {
+		require.Equal(t, i, result.Index)
+	}
+	require.Equal(t, payload.ImportCreated, resp.Results[499].Status)
+	require.Equal(t, payload.ImportFailed, resp.Results[500].Status)
+	require.Equal(t, "provider table is locked", resp.Results[999].Error)
+	require.Equal(t, payload.ImportCreated, resp.Results[1000].Status)
+	require.Equal(t, payload.ImportUnchanged, resp.Results[1099].Status)
+	require.Equal(t, payload.Skills{"backend"}, repo.tasks[0].Skills)
+}
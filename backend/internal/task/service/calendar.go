@@ -0,0 +1,245 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/config"
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// dateLayout is the format of calendar dates in requests and configuration.
+const dateLayout = "2006-01-02"
+
+// ErrInvalidCalendar is returned when a working calendar cannot be built from a request.
+var ErrInvalidCalendar = errors.New("invalid working calendar")
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// Calendar describes when the team works. Weeks are counted in blocks of
+// seven days from the start date of the plan.
+type Calendar struct {
+	hoursPerDay float64
+	dayStart    float64
+	workDays    [7]bool
+	holidays    map[string]bool
+	start       time.Time
+
+	weekHours []float64
+}
+
+// NewCalendar creates a calendar starting at the given date. Working days
+// begin dayStart hours after midnight.
+func NewCalendar(start time.Time, hoursPerDay, dayStart float64, workDays []time.Weekday, holidays []time.Time) *Calendar {
+	c := &Calendar{
+		hoursPerDay: hoursPerDay,
+		dayStart:    dayStart,
+		holidays:    map[string]bool{},
+		start:       truncateToDay(start),
+	}
+	for _, day := range workDays {
+		c.workDays[day] = true
+	}
+	for _, holiday := range holidays {
+		c.holidays[holiday.Format(dateLayout)] = true
+	}
+	return c
+}
+
+// calendarFromRequest builds the calendar of a scheduling request. Values
+// missing from the request are taken from the application configuration.
+func calendarFromRequest(req payload.ScheduleAssignmentRequest) (*Calendar, error) {
+	conf := config.GetApp()
+
+	hoursPerDay := conf.WorkHoursPerDay
+	if req.HoursPerDay > 0 {
+		hoursPerDay = req.HoursPerDay
+	}
+	if hoursPerDay <= 0 || hoursPerDay > 24 {
+		return nil, fmt.Errorf("%w: hours per day must be between 0 and 24, got %v", ErrInvalidCalendar, hoursPerDay)
+	}
+
+	dayStart := conf.WorkDayStartHour
+	if req.DayStartHour != nil {
+		dayStart = *req.DayStartHour
+	}
+	if dayStart < 0 || dayStart > 23 {
+		return nil, fmt.Errorf("%w: the day start hour must be between 0 and 23, got %v", ErrInvalidCalendar, dayStart)
+	}
+	if dayStart+hoursPerDay > 24 {
+		return nil, fmt.Errorf("%w: a working day starting at hour %v with %v hours does not fit into a day", ErrInvalidCalendar, dayStart, hoursPerDay)
+	}
+
+	dayNames := conf.WorkDays
+	if len(req.WorkDays) > 0 {
+		dayNames = req.WorkDays
+	}
+	workDays, err := parseWeekdays(dayNames)
+	if err != nil {
+		return nil, err
+	}
+
+	holidayDates := conf.PublicHolidays
+	if len(req.Holidays) > 0 {
+		holidayDates = req.Holidays
+	}
+	holidays, err := parseDates(holidayDates)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now().UTC()
+	startDate := conf.PlanStartDate
+	if req.StartDate != "" {
+		startDate = req.StartDate
+	}
+	if startDate != "" {
+		start, err = time.Parse(dateLayout, startDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: start date %q must use the format %s", ErrInvalidCalendar, startDate, dateLayout)
+		}
+	}
+
+	return NewCalendar(start, hoursPerDay, dayStart, workDays, holidays), nil
+}
+
+func parseWeekdays(names []string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		day, ok := weekdays[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown work day %q", ErrInvalidCalendar, name)
+		}
+		days = append(days, day)
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("%w: at least one work day is required", ErrInvalidCalendar)
+	}
+	return days, nil
+}
+
+func parseDates(values []string) ([]time.Time, error) {
+	var dates []time.Time
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse(dateLayout, value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: date %q must use the format %s", ErrInvalidCalendar, value, dateLayout)
+		}
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
+
+// Start returns the first day of the plan.
+func (c *Calendar) Start() time.Time {
+	return c.start
+}
+
+// HoursPerDay returns the working hours of a full working day.
+func (c *Calendar) HoursPerDay() float64 {
+	return c.hoursPerDay
+}
+
+// DayStart returns the moment work begins on the given date.
+func (c *Calendar) DayStart(date time.Time) time.Time {
+	return truncateToDay(date).Add(time.Duration(c.dayStart * float64(time.Hour)))
+}
+
+// IsWorkDay reports whether the team works on the given date.
+func (c *Calendar) IsWorkDay(date time.Time) bool {
+	return c.workDays[date.Weekday()] && !c.holidays[date.Format(dateLayout)]
+}
+
+// WeekStart returns the first calendar day of a week.
+func (c *Calendar) WeekStart(week int) time.Time {
+	return c.start.AddDate(0, 0, 7*week)
+}
+
+// WeekEnd returns the last calendar day of a week.
+func (c *Calendar) WeekEnd(week int) time.Time {
+	return c.start.AddDate(0, 0, 7*week+6)
+}
+
+// Days returns the working days of a week.
+func (c *Calendar) Days(week int) []time.Time {
+	var days []time.Time
+	for i := 0; i < 7; i++ {
+		if day := c.WeekStart(week).AddDate(0, 0, i); c.IsWorkDay(day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// WeekHours returns the working hours of a week.
+func (c *Calendar) WeekHours(week int) float64 {
+	for len(c.weekHours) <= week {
+		c.weekHours = append(c.weekHours, float64(len(c.Days(len(c.weekHours))))*c.hoursPerDay)
+	}
+	return c.weekHours[week]
+}
+
+// WorkDaysPerWeek returns the number of work days in a week without holidays.
+func (c *Calendar) WorkDaysPerWeek() int {
+	days := 0
+	for _, works := range c.workDays {
+		if works {
+			days++
+		}
+	}
+	return days
+}
+
+// HoursBefore returns the working hours of all weeks before the given one.
+func (c *Calendar) HoursBefore(week int) float64 {
+	hours := 0.0
+	for w := 0; w < week; w++ {
+		hours += c.WeekHours(w)
+	}
+	return hours
+}
+
+// HoursBeforeDay returns the working hours of the days in the week of a date before that date.
+func (c *Calendar) HoursBeforeDay(date time.Time) float64 {
+	hours := 0.0
+	weekStart := c.start.AddDate(0, 0, int(date.Sub(c.start).Hours()/24)/7*7)
+	for day := weekStart; day.Before(date); day = day.AddDate(0, 0, 1) {
+		if c.IsWorkDay(day) {
+			hours += c.hoursPerDay
+		}
+	}
+	return hours
+}
+
+// WorkDaysUntil counts the working days from the start of the plan up to and including the given date.
+func (c *Calendar) WorkDaysUntil(date time.Time) int {
+	count := 0
+	for day := c.start; !day.After(date); day = day.AddDate(0, 0, 1) {
+		if c.IsWorkDay(day) {
+			count++
+		}
+	}
+	return count
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
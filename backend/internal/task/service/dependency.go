@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// ErrDependencyCycle is returned when a dependency would make a task wait for itself.
+var ErrDependencyCycle = errors.New("dependency cycle")
+
+// CreateTaskDependency implements Service.
+func (s *service) CreateTaskDependency(ctx context.Context, req payload.CreateTaskDependencyRequest) (payload.CreateTaskDependencyResponse, error) {
+	s.logger.Trace("Creating task dependency taskId=%v, dependsOnId=%v", req.TaskID, req.DependsOnID)
+
+	dependencies, err := s.fetchDependencies(ctx)
+	if err != nil {
+		return payload.CreateTaskDependencyResponse{}, err
+	}
+	if path := dependencyPath(dependencies, req.DependsOnID, req.TaskID); path != nil {
+		s.logger.Warn("Task dependency would create a cycle taskId=%v, dependsOnId=%v", req.TaskID, req.DependsOnID)
+		return payload.CreateTaskDependencyResponse{}, fmt.Errorf("%w: task %v already depends on task %v via %v", ErrDependencyCycle, req.DependsOnID, req.TaskID, path)
+	}
+
+	resp, err := s.repository.CreateTaskDependency(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to create task dependency taskId=%v, dependsOnId=%v: error=%v", req.TaskID, req.DependsOnID, err)
+		return resp, err
+	}
+	s.logger.Trace("Task dependency created successfully id=%v", resp.ID)
+	return resp, nil
+}
+
+// ListTaskDependencies implements Service.
+func (s *service) ListTaskDependencies(ctx context.Context, req payload.ListTaskDependenciesRequest) (payload.ListTaskDependenciesResponse, error) {
+	s.logger.Trace("Listing task dependencies taskId=%v", req.TaskID)
+	resp, err := s.repository.ListTaskDependencies(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to list task dependencies taskId=%v: error=%v", req.TaskID, err)
+		return resp, err
+	}
+	s.logger.Trace("Task dependencies listed successfully taskId=%v", req.TaskID)
+	return resp, nil
+}
+
+// DeleteTaskDependency implements Service.
+func (s *service) DeleteTaskDependency(ctx context.Context, req payload.DeleteTaskDependencyRequest) error {
+	s.logger.Trace("Deleting task dependency taskId=%v, dependsOnId=%v", req.TaskID, req.DependsOnID)
+	if err := s.repository.DeleteTaskDependency(ctx, req); err != nil {
+		s.logger.Error("Failed to delete task dependency taskId=%v, dependsOnId=%v: error=%v", req.TaskID, req.DependsOnID, err)
+		return err
+	}
+	s.logger.Trace("Task dependency deleted successfully taskId=%v, dependsOnId=%v", req.TaskID, req.DependsOnID)
+	return nil
+}
+
+// fetchDependencies retrieves the dependencies of all tasks from the repository.
+func (s *service) fetchDependencies(ctx context.Context) ([]payload.TaskDependency, error) {
+	dependenciesResp, err := s.repository.ListTaskDependencies(ctx, payload.ListTaskDependenciesRequest{})
+	if err != nil {
+		s.logger.Error("Failed to list task dependencies: error=%v", err)
+		return nil, err
+	}
+	return dependenciesResp.Dependencies, nil
+}
+
+// dependencyPath returns the chain of task IDs through which from depends on
+// to, or nil when it does not. A task trivially depends on itself.
+func dependencyPath(dependencies []payload.TaskDependency, from, to uint) []uint {
+	prerequisites := map[uint][]uint{}
+	for _, d := range dependencies {
+		prerequisites[d.TaskID] = append(prerequisites[d.TaskID], d.DependsOnID)
+	}
+
+	visited := map[uint]bool{}
+	var walk func(id uint) []uint
+	walk = func(id uint) []uint {
+		if id == to {
+			return []uint{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		for _, next := range prerequisites[id] {
+			if path := walk(next); path != nil {
+				return append([]uint{id}, path...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
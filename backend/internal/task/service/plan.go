@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// defaultPlanName is used for plans saved without a name.
+const defaultPlanName = "default"
+
+// CreatePlan implements Service. The schedule is computed with the given
+// options and saved as the next version of the named plan.
+func (s *service) CreatePlan(ctx context.Context, req payload.CreatePlanRequest) (payload.Plan, error) {
+	if req.Name == "" {
+		req.Name = defaultPlanName
+	}
+	s.logger.Trace("Creating plan name=%v, strategy=%v", req.Name, req.Options.Strategy)
+
+	schedule, err := s.ScheduleAssignments(ctx, req.Options)
+	if err != nil {
+		return payload.Plan{}, err
+	}
+
+	plan, err := s.repository.CreatePlan(ctx, payload.Plan{
+		Name:       req.Name,
+		Strategy:   schedule.Strategy,
+		Makespan:   schedule.Makespan,
+		FinishDate: schedule.FinishDate,
+		TaskCount:  len(planPlacements(schedule)),
+		Options:    req.Options,
+		Schedule:   schedule,
+	})
+	if err != nil {
+		s.logger.Error("Failed to save plan name=%v: error=%v", req.Name, err)
+		return plan, err
+	}
+	s.logger.Trace("Plan created successfully id=%v, name=%v, version=%v", plan.ID, plan.Name, plan.Version)
+	return plan, nil
+}
+
+// GetPlan implements Service.
+func (s *service) GetPlan(ctx context.Context, req payload.GetPlanRequest) (payload.Plan, error) {
+	s.logger.Trace("Getting plan id=%v", req.ID)
+	plan, err := s.repository.GetPlan(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to get plan id=%v: error=%v", req.ID, err)
+		return plan, err
+	}
+	return plan, nil
+}
+
+// ListPlans implements Service.
+func (s *service) ListPlans(ctx context.Context, req payload.ListPlansRequest) (payload.ListPlansResponse, error) {
+	s.logger.Trace("Listing plans limit=%v, offset=%v", req.Limit, req.Offset)
+	resp, err := s.repository.ListPlans(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to list plans: error=%v", err)
+		return resp, err
+	}
+	s.logger.Trace("Plans listed successfully count=%v", len(resp.Plans))
+	return resp, nil
+}
+
+// DiffPlans implements Service. Tasks are compared by the developers working
+// on them and the weeks they start and end in.
+func (s *service) DiffPlans(ctx context.Context, req payload.DiffPlansRequest) (payload.DiffPlansResponse, error) {
+	s.logger.Trace("Comparing plans id=%v, otherId=%v", req.ID, req.OtherID)
+
+	from, err := s.GetPlan(ctx, payload.GetPlanRequest{ID: req.ID})
+	if err != nil {
+		return payload.DiffPlansResponse{}, err
+	}
+	to, err := s.GetPlan(ctx, payload.GetPlanRequest{ID: req.OtherID})
+	if err != nil {
+		return payload.DiffPlansResponse{}, err
+	}
+
+	resp := diffSchedules(from.Schedule, to.Schedule)
+	resp.From, resp.To = planSummary(from), planSummary(to)
+	resp.MakespanDelta = to.Makespan - from.Makespan
+	if from.FinishDate != nil && to.FinishDate != nil {
+		resp.FinishDelta = int(math.Round(to.FinishDate.Sub(*from.FinishDate).Hours() / 24))
+	}
+
+	s.logger.Trace("Plans compared successfully id=%v, otherId=%v, added=%v, removed=%v, moved=%v", req.ID, req.OtherID, len(resp.Added), len(resp.Removed), len(resp.Moved))
+	return resp, nil
+}
+
+// diffSchedules reports the tasks that were added, removed or moved between
+// two schedules.
+func diffSchedules(from, to payload.ScheduleAssignmentResponse) payload.DiffPlansResponse {
+	before, after := planPlacements(from), planPlacements(to)
+	resp := payload.DiffPlansResponse{
+		Added:   []payload.TaskChange{},
+		Removed: []payload.TaskChange{},
+		Moved:   []payload.TaskChange{},
+	}
+
+	for id, next := range after {
+		prev, ok := before[id]
+		switch {
+		case !ok:
+			resp.Added = append(resp.Added, payload.TaskChange{Task: next.task, To: &next.TaskPlacement})
+		case prev.samePlace(next):
+			resp.Unchanged++
+		default:
+			resp.Moved = append(resp.Moved, payload.TaskChange{Task: next.task, From: &prev.TaskPlacement, To: &next.TaskPlacement})
+		}
+	}
+	for id, prev := range before {
+		if _, ok := after[id]; !ok {
+			resp.Removed = append(resp.Removed, payload.TaskChange{Task: prev.task, From: &prev.TaskPlacement})
+		}
+	}
+
+	for _, changes := range [][]payload.TaskChange{resp.Added, resp.Removed, resp.Moved} {
+		sort.Slice(changes, func(i, j int) bool { return changes[i].Task.ID < changes[j].Task.ID })
+	}
+	return resp
+}
+
+// taskPlacement is where a task ended up in a schedule.
+type taskPlacement struct {
+	payload.TaskPlacement
+	task payload.Task
+}
+
+func (p taskPlacement) samePlace(other taskPlacement) bool {
+	return p.StartWeek == other.StartWeek && p.EndWeek == other.EndWeek && sameIDs(p.DeveloperIDs, other.DeveloperIDs)
+}
+
+// sameIDs reports whether two sorted lists of IDs are equal.
+func sameIDs(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// planPlacements collects the developers and weeks of every assigned task.
+func planPlacements(schedule payload.ScheduleAssignmentResponse) map[uint]taskPlacement {
+	placements := make(map[uint]taskPlacement)
+	for _, assignment := range schedule.Assignments {
+		for _, devTasks := range assignment.DeveloperTasks {
+			for _, task := range devTasks.Tasks {
+				p, ok := placements[task.ID]
+				if !ok {
+					p = taskPlacement{task: task, TaskPlacement: payload.TaskPlacement{StartWeek: assignment.Week, EndWeek: assignment.Week}}
+				}
+				p.StartWeek = min(p.StartWeek, assignment.Week)
+				p.EndWeek = max(p.EndWeek, assignment.Week)
+				if !containsID(p.DeveloperIDs, devTasks.Developer.ID) {
+					p.DeveloperIDs = append(p.DeveloperIDs, devTasks.Developer.ID)
+					sort.Slice(p.DeveloperIDs, func(i, j int) bool { return p.DeveloperIDs[i] < p.DeveloperIDs[j] })
+				}
+				placements[task.ID] = p
+			}
+		}
+	}
+	return placements
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func planSummary(plan payload.Plan) payload.PlanSummary {
+	return payload.PlanSummary{
+		ID:         plan.ID,
+		Name:       plan.Name,
+		Version:    plan.Version,
+		Strategy:   plan.Strategy,
+		Makespan:   plan.Makespan,
+		FinishDate: plan.FinishDate,
+		TaskCount:  plan.TaskCount,
+		CreatedAt:  plan.CreatedAt,
+	}
+}
@@ -0,0 +1,222 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+	"github.com/mehmetali10/task-planner/internal/task/service"
+	"github.com/stretchr/testify/require"
+)
+
+func (f *fakeRepo) CreatePlan(ctx context.Context, plan payload.Plan) (payload.Plan, error) {
+	plan.ID = uint(len(f.plans) + 1)
+	plan.Version = 1
+	for _, existing := range f.plans {
+		if existing.Name == plan.Name && existing.Version >= plan.Version {
+			plan.Version = existing.Version + 1
+		}
+	}
+	f.plans = append(f.plans, plan)
+	return plan, nil
+}
+
+func (f *fakeRepo) GetPlan(ctx context.Context, req payload.GetPlanRequest) (payload.Plan, error) {
+	for _, plan := range f.plans {
+		if plan.ID == req.ID {
+			return plan, nil
+		}
+	}
+	return payload.Plan{}, fmt.Errorf("plan with id=%v: %w", req.ID, repository.ErrNotFound)
+}
+
+func TestPlans(t *testing.T) {
+	t.Run("Versions", func(t *testing.T) {
+		repo := &fakeRepo{tasks: seedTasks(10), developers: seedDevelopers()}
+		svc := newScheduleService(t, repo)
+
+		first, err := svc.CreatePlan(context.Background(), payload.CreatePlanRequest{Name: "q3", Options: payload.ScheduleAssignmentRequest{Strategy: "lpt"}})
+		require.NoError(t, err)
+		require.Equal(t, 1, first.Version)
+		require.Equal(t, "lpt", first.Strategy)
+		require.Equal(t, 10, first.TaskCount)
+		require.Equal(t, first.Schedule.Makespan, first.Makespan)
+
+		second, err := svc.CreatePlan(context.Background(), payload.CreatePlanRequest{Name: "q3"})
+		require.NoError(t, err)
+		require.Equal(t, 2, second.Version)
+
+		unnamed, err := svc.CreatePlan(context.Background(), payload.CreatePlanRequest{})
+		require.NoError(t, err)
+		require.Equal(t, "default", unnamed.Name)
+		require.Equal(t, 1, unnamed.Version)
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		repo := &fakeRepo{
+			tasks: []payload.Task{
+				{ID: 1, Name: "A", Difficulty: 9, Duration: 1},
+				{ID: 2, Name: "B", Difficulty: 9, Duration: 1},
+			},
+			developers: []payload.Developer{{ID: 1, FirstName: "DEV1", Capacity: 1}},
+		}
+		svc := newScheduleService(t, repo)
+
+		before, err := svc.CreatePlan(context.Background(), payload.CreatePlanRequest{})
+		require.NoError(t, err)
+
+		// A new urgent task arrives, is planned first and pushes the others
+		// back, while task 2 leaves the backlog
+		repo.tasks = []payload.Task{
+			{ID: 1, Name: "A", Difficulty: 9, Duration: 1},
+			{ID: 3, Name: "C", Difficulty: 45, Duration: 1, Priority: 5},
+		}
+		after, err := svc.CreatePlan(context.Background(), payload.CreatePlanRequest{})
+		require.NoError(t, err)
+
+		diff, err := svc.DiffPlans(context.Background(), payload.DiffPlansRequest{ID: before.ID, OtherID: after.ID})
+		require.NoError(t, err)
+		require.Equal(t, before.ID, diff.From.ID)
+		require.Equal(t, after.ID, diff.To.ID)
+		require.Equal(t, after.Makespan-before.Makespan, diff.MakespanDelta)
+		require.Greater(t, diff.FinishDelta, 0)
+
+		require.Len(t, diff.Added, 1)
+		require.Equal(t, uint(3), diff.Added[0].Task.ID)
+		require.Nil(t, diff.Added[0].From)
+		require.Len(t, diff.Removed, 1)
+		require.Equal(t, uint(2), diff.Removed[0].Task.ID)
+		require.Nil(t, diff.Removed[0].To)
+		require.Len(t, diff.Moved, 1)
+		require.Equal(t, uint(1), diff.Moved[0].Task.ID)
+		require.Equal(t, uint(1), diff.Moved[0].From.StartWeek)
+		require.Equal(t, uint(2), diff.Moved[0].To.StartWeek)
+		require.Zero(t, diff.Unchanged)
+
+		same, err := svc.DiffPlans(context.Background(), payload.DiffPlansRequest{ID: after.ID, OtherID: after.ID})
+		require.NoError(t, err)
+		require.Empty(t, same.Moved)
+		require.Equal(t, 2, same.Unchanged)
+	})
+
+	t.Run("Reschedule", func(t *testing.T) {
+		repo := &fakeRepo{
+			tasks: []payload.Task{
+				{ID: 1, Name: "A", Difficulty: 40, Duration: 1},
+				{ID: 2, Name: "B", Difficulty: 40, Duration: 1},
+				{ID: 3, Name: "C", Difficulty: 40, Duration: 1},
+				{ID: 4, Name: "D", Difficulty: 40, Duration: 1},
+			},
+			developers: []payload.Developer{
+				{ID: 1, FirstName: "DEV1", Capacity: 1},
+				{ID: 2, FirstName: "DEV2", Capacity: 1},
+			},
+		}
+		svc := newScheduleService(t, repo)
+
+		base, err := svc.CreatePlan(context.Background(), payload.CreatePlanRequest{Options: payload.ScheduleAssignmentRequest{StartDate: "2026-01-05"}})
+		require.NoError(t, err)
+		require.Len(t, base.Schedule.Assignments, 2)
+
+		// An urgent task arrives after the first week, while task 3 is in progress
+		repo.tasks = append(repo.tasks, payload.Task{ID: 5, Name: "E", Difficulty: 40, Duration: 1, Priority: 5})
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{
+			BasePlanID:    base.ID,
+			FrozenTaskIDs: []uint{3},
+			AsOf:          "2026-01-12",
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.Reschedule)
+		require.Equal(t, 1, resp.Reschedule.ElapsedWeeks)
+		require.Equal(t, []uint{1, 2, 3}, resp.Reschedule.FrozenTasks)
+		require.Empty(t, resp.Reschedule.MovedTasks)
+		require.Equal(t, "2026-01-05", resp.StartDate.Format("2006-01-02"))
+
+		before := map[uint]uint{}
+		for _, assignment := range base.Schedule.Assignments {
+			for _, devTasks := range assignment.DeveloperTasks {
+				for _, task := range devTasks.Tasks {
+					before[task.ID] = assignment.Week
+				}
+			}
+		}
+		after := map[uint]uint{}
+		for _, assignment := range resp.Assignments {
+			for _, devTasks := range assignment.DeveloperTasks {
+				for _, task := range devTasks.Tasks {
+					after[task.ID] = assignment.Week
+				}
+			}
+		}
+		for id, week := range before {
+			require.Equal(t, week, after[id], "task %d moved", id)
+		}
+		require.Equal(t, uint(3), after[5])
+
+		// Recomputing from scratch puts the urgent task first and moves the others
+		fresh, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{StartDate: "2026-01-05"})
+		require.NoError(t, err)
+		require.Nil(t, fresh.Reschedule)
+		require.Contains(t, fresh.Assignments[0].DeveloperTasks[0].Tasks, repo.tasks[4])
+	})
+
+	t.Run("RescheduleBehindNewTask", func(t *testing.T) {
+		repo := &fakeRepo{
+			tasks: []payload.Task{
+				{ID: 1, Name: "A", Difficulty: 9, Duration: 1},
+				{ID: 2, Name: "B", Difficulty: 9, Duration: 1},
+			},
+			developers: []payload.Developer{{ID: 1, FirstName: "DEV1", Capacity: 1}},
+		}
+		svc := newScheduleService(t, repo)
+
+		base, err := svc.CreatePlan(context.Background(), payload.CreatePlanRequest{Options: payload.ScheduleAssignmentRequest{StartDate: "2026-01-05"}})
+		require.NoError(t, err)
+
+		// Task 2 of the plan now waits for a new task, which is not booked
+		// when the kept tasks are placed
+		repo.tasks = append(repo.tasks, payload.Task{ID: 3, Name: "C", Difficulty: 9, Duration: 1})
+		repo.dependencies = []payload.TaskDependency{{ID: 1, TaskID: 2, DependsOnID: 3}}
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{
+			BasePlanID: base.ID,
+			AsOf:       "2026-01-05",
+			Timeline:   true,
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Unassignable)
+
+		start, end := map[uint]time.Time{}, map[uint]time.Time{}
+		for _, developer := range resp.Timeline {
+			for _, slot := range developer.Slots {
+				start[slot.Task.ID], end[slot.Task.ID] = slot.Start, slot.End
+			}
+		}
+		require.Len(t, start, 3)
+		require.False(t, start[2].Before(end[3]))
+	})
+
+	t.Run("InvalidReschedule", func(t *testing.T) {
+		svc := newScheduleService(t, &fakeRepo{tasks: seedTasks(2), developers: seedDevelopers()})
+
+		_, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{FrozenTaskIDs: []uint{1}})
+		require.ErrorIs(t, err, service.ErrInvalidReschedule)
+
+		base, err := svc.CreatePlan(context.Background(), payload.CreatePlanRequest{})
+		require.NoError(t, err)
+		_, err = svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{BasePlanID: base.ID, AsOf: "next week"})
+		require.ErrorIs(t, err, service.ErrInvalidReschedule)
+
+		_, err = svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{BasePlanID: 99})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		svc := newScheduleService(t, &fakeRepo{})
+
+		_, err := svc.DiffPlans(context.Background(), payload.DiffPlansRequest{ID: 1, OtherID: 2})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
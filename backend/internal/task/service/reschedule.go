@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// ErrInvalidReschedule is returned when a request cannot be rescheduled from a saved plan.
+var ErrInvalidReschedule = errors.New("invalid rescheduling request")
+
+// basePlan loads the saved plan a request reschedules from. It returns nil
+// when the request plans from scratch.
+func (s *service) basePlan(ctx context.Context, req payload.ScheduleAssignmentRequest) (*payload.Plan, error) {
+	if req.BasePlanID == 0 {
+		if len(req.FrozenTaskIDs) > 0 || req.AsOf != "" {
+			return nil, fmt.Errorf("%w: frozen tasks and the as-of date require a base plan", ErrInvalidReschedule)
+		}
+		return nil, nil
+	}
+	plan, err := s.GetPlan(ctx, payload.GetPlanRequest{ID: req.BasePlanID})
+	if err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
+// elapsedWeeks returns the number of weeks of the calendar that are over on
+// the as-of date, today if it is empty.
+func elapsedWeeks(calendar *Calendar, asOf string) (int, error) {
+	day := time.Now().UTC()
+	if asOf != "" {
+		var err error
+		if day, err = time.Parse(dateLayout, asOf); err != nil {
+			return 0, fmt.Errorf("%w: as-of date %q must use the format %s", ErrInvalidReschedule, asOf, dateLayout)
+		}
+	}
+	days := int(truncateToDay(day).Sub(calendar.Start()).Hours() / 24)
+	if days <= 0 {
+		return 0, nil
+	}
+	return days / 7, nil
+}
+
+// reschedule plans the tasks again, starting from a saved plan. Tasks that
+// are frozen, in progress, done or were booked into elapsed weeks have been
+// started and are pinned where the plan put them. The other tasks of the plan stay with their
+// developer when that developer can still take them, and only new tasks, or
+// tasks that have to move, are left to the scheduler.
+func reschedule(tt *Timetable, scheduler Scheduler, tasks []payload.Task, base payload.Plan, frozenIDs []uint, elapsed int) *payload.Reschedule {
+	current := make(map[uint]payload.Task, len(tasks))
+	frozen := make(map[uint]bool, len(frozenIDs))
+	for _, task := range tasks {
+		if task.Status == payload.StatusCancelled {
+			continue
+		}
+		current[task.ID] = task
+		if task.Status == payload.StatusInProgress || task.Status == payload.StatusDone {
+			frozen[task.ID] = true
+		}
+	}
+	devIndex := make(map[uint]int, len(tt.Developers()))
+	for i, developer := range tt.Developers() {
+		devIndex[developer.ID] = i
+	}
+
+	for _, id := range frozenIDs {
+		frozen[id] = true
+	}
+	for _, assignment := range base.Schedule.Assignments {
+		if int(assignment.Week) <= elapsed {
+			for _, devTasks := range assignment.DeveloperTasks {
+				for _, task := range devTasks.Tasks {
+					frozen[task.ID] = true
+				}
+			}
+		}
+	}
+
+	// Pin the started tasks, and remember who worked on the others, in the
+	// order of the saved plan
+	pinned := map[uint]bool{}
+	previous := map[uint]int{}
+	var kept []payload.Task
+	for _, assignment := range base.Schedule.Assignments {
+		week := int(assignment.Week) - 1
+		for _, devTasks := range assignment.DeveloperTasks {
+			dev, ok := devIndex[devTasks.Developer.ID]
+			if !ok {
+				continue
+			}
+			for _, planned := range devTasks.Tasks {
+				task, ok := current[planned.ID]
+				if !ok {
+					continue
+				}
+				if !frozen[task.ID] {
+					if _, seen := previous[task.ID]; !seen {
+						previous[task.ID] = dev
+						kept = append(kept, task)
+					}
+					continue
+				}
+				hours, part, parts := pinnedHours(tt, planned, dev, devTasks.Chunks)
+				if math.IsInf(hours, 0) || math.IsNaN(hours) {
+					continue
+				}
+				tt.Pin(task, dev, week, hours, part, parts)
+				pinned[task.ID] = true
+			}
+		}
+	}
+
+	var fresh []payload.Task
+	for _, task := range tasks {
+		if _, ok := previous[task.ID]; !ok && !pinned[task.ID] && !task.Status.Closed() {
+			fresh = append(fresh, task)
+		}
+	}
+	// Kept tasks that cannot stay, also those waiting for tasks that are not
+	// booked yet, are left to the scheduler with the new ones
+	placed := map[uint]bool{}
+	for _, task := range tt.Order(tt.Placeable(withoutPinned(kept, pinned))) {
+		if week, ok := tt.FirstFit(task, previous[task.ID]); ok {
+			tt.Assign(task, previous[task.ID], week)
+			placed[task.ID] = true
+		}
+	}
+	for _, task := range withoutPinned(kept, pinned) {
+		if !placed[task.ID] {
+			fresh = append(fresh, task)
+		}
+	}
+	scheduler.Schedule(tt, withoutPinned(fresh, pinned))
+
+	summary := &payload.Reschedule{
+		BasePlanID:   base.ID,
+		ElapsedWeeks: elapsed,
+		FrozenTasks:  []uint{},
+		MovedTasks:   []uint{},
+	}
+	for id := range pinned {
+		summary.FrozenTasks = append(summary.FrozenTasks, id)
+	}
+	before := planPlacements(base.Schedule)
+	for id, after := range planPlacements(payload.ScheduleAssignmentResponse{Assignments: tt.Assignments()}) {
+		if prev, ok := before[id]; ok && !sameIDs(prev.DeveloperIDs, after.DeveloperIDs) {
+			summary.MovedTasks = append(summary.MovedTasks, id)
+		}
+	}
+	sort.Slice(summary.FrozenTasks, func(i, j int) bool { return summary.FrozenTasks[i] < summary.FrozenTasks[j] })
+	sort.Slice(summary.MovedTasks, func(i, j int) bool { return summary.MovedTasks[i] < summary.MovedTasks[j] })
+	return summary
+}
+
+// pinnedHours returns the hours a developer spends on a started task in one
+// week of the saved plan, and which part of the task it is. The task is the
+// one stored with the plan, so the hours follow the progress at that time.
+func pinnedHours(tt *Timetable, task payload.Task, dev int, chunks []payload.TaskChunk) (float64, int, int) {
+	for _, c := range chunks {
+		if c.TaskID == task.ID {
+			return c.Hours, c.Part, c.Parts
+		}
+	}
+	return tt.Effort(task, dev), 0, 0
+}
+
+// withoutPinned drops the tasks that are pinned already.
+func withoutPinned(tasks []payload.Task, pinned map[uint]bool) []payload.Task {
+	var remaining []payload.Task
+	for _, task := range tasks {
+		if !pinned[task.ID] {
+			remaining = append(remaining, task)
+		}
+	}
+	return remaining
+}
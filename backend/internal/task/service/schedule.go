@@ -2,13 +2,60 @@ package service
 
 import (
 	"context"
-	"sort"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 
+	"github.com/mehmetali10/task-planner/internal/pkg/config"
 	"github.com/mehmetali10/task-planner/internal/pkg/payload"
 )
 
+// ErrInvalidSplitMode is returned when a request selects an unknown way of splitting tasks.
+var ErrInvalidSplitMode = errors.New("invalid task split mode")
+
+var splitModes = map[string]SplitMode{
+	"none":       SplitNone,
+	"weeks":      SplitWeeks,
+	"developers": SplitDevelopers,
+}
+
 func (s *service) ScheduleAssignments(ctx context.Context, req payload.ScheduleAssignmentRequest) (payload.ScheduleAssignmentResponse, error) {
-	s.logger.Trace("Scheduling assignments")
+	s.logger.Trace("Scheduling assignments strategy=%v", req.Strategy)
+
+	scheduler, err := getScheduler(req.Strategy)
+	if err != nil {
+		s.logger.Warn("Failed to select scheduler strategy=%v: error=%v", req.Strategy, err)
+		return payload.ScheduleAssignmentResponse{}, err
+	}
+
+	base, err := s.basePlan(ctx, req)
+	if err != nil {
+		s.logger.Warn("Failed to load base plan id=%v: error=%v", req.BasePlanID, err)
+		return payload.ScheduleAssignmentResponse{}, err
+	}
+	if base != nil && req.StartDate == "" && base.Schedule.StartDate != nil {
+		// Weeks are counted from the start of the base plan so they line up with it
+		req.StartDate = base.Schedule.StartDate.Format(dateLayout)
+	}
+
+	calendar, err := calendarFromRequest(req)
+	if err != nil {
+		s.logger.Warn("Failed to build working calendar: error=%v", err)
+		return payload.ScheduleAssignmentResponse{}, err
+	}
+
+	elapsed, err := elapsedWeeks(calendar, req.AsOf)
+	if err != nil {
+		s.logger.Warn("Failed to count elapsed weeks: error=%v", err)
+		return payload.ScheduleAssignmentResponse{}, err
+	}
+
+	split, err := splitModeFromRequest(req)
+	if err != nil {
+		s.logger.Warn("Failed to select task splitting: error=%v", err)
+		return payload.ScheduleAssignmentResponse{}, err
+	}
 
 	// Fetch the list of tasks and developers
 	tasks, err := s.fetchTasks(ctx)
@@ -21,67 +68,136 @@ func (s *service) ScheduleAssignments(ctx context.Context, req payload.ScheduleA
 		return payload.ScheduleAssignmentResponse{}, err
 	}
 
+	// Done and cancelled tasks need no more work
+	open := openTasks(tasks)
+
+	s.logger.Trace("Scheduling tasks considered=%v of %v", len(open), len(tasks))
+
 	// Return empty response if no tasks or developers
-	if len(tasks) == 0 || len(developers) == 0 {
+	if len(open) == 0 || len(developers) == 0 {
 		s.logger.Warn("No tasks or developers available")
-		return payload.ScheduleAssignmentResponse{}, nil
+		return payload.ScheduleAssignmentResponse{ConsideredTasks: len(open)}, nil
 	}
 
-	// Constants for weekly work hours and days
-	const weeklyWorkHours = 45 // 45 hours per week
-	const workDaysInWeek = 5   // 5 workdays per week
-	assignments := []payload.Assignment{}
-	totalWeeks := 0
-	totalElapsedWorkHour := 0.0
-
-	// Sort tasks by duration in descending order
-	sort.Slice(tasks, func(i, j int) bool {
-		return tasks[i].Duration > tasks[j].Duration
-	})
-
-	remainingTasks := tasks
-	for len(remainingTasks) > 0 {
-		// Create an assignment for the week
-		var assignment payload.Assignment
-		var newRemainingTasks []payload.Task
-
-		assignment, newRemainingTasks, totalElapsedWorkHour = s.assignTasksToDevelopers(remainingTasks, developers, weeklyWorkHours, totalElapsedWorkHour)
-
-		// If tasks are assigned, process the result
-		if len(assignment.DeveloperTasks) > 0 {
-			assignments = append(assignments, assignment)
-			totalWeeks++
+	absences, err := s.fetchAbsences(ctx)
+	if err != nil {
+		return payload.ScheduleAssignmentResponse{}, err
+	}
 
-			// Log weekly progress
-			totalDays := totalWeeks * workDaysInWeek
-			s.logger.Trace("Week %d completed, total duration so far: %d days", totalWeeks, totalDays)
-		}
+	dependencies, err := s.fetchDependencies(ctx)
+	if err != nil {
+		return payload.ScheduleAssignmentResponse{}, err
+	}
 
-		// Update remaining tasks for the next iteration
-		remainingTasks = newRemainingTasks
+	tt := NewTimetable(developers, calendar, absences)
+	if err := tt.SetDependencies(open, dependencies); err != nil {
+		s.logger.Warn("Failed to apply task dependencies: error=%v", err)
+		return payload.ScheduleAssignmentResponse{}, err
 	}
+	tt.SetSkillMatching(skillMatchingFromRequest(req))
+	tt.SetSplitting(split)
+	tt.SetHorizon(config.GetApp().MaxPlanWeeks)
+	var rescheduled *payload.Reschedule
+	if base != nil {
+		tt.SetFirstWeek(elapsed)
+		rescheduled = reschedule(tt, scheduler, tasks, *base, req.FrozenTaskIDs, elapsed)
+	} else {
+		scheduler.Schedule(tt, open)
+	}
+	criticalPath, criticalPathHours := tt.CriticalPath()
 
-	// Calculate the minimum total days and hours
-	minDays := totalWeeks * workDaysInWeek
+	// Calculate the minimum total weeks, days and hours
+	totalWeeks := tt.Weeks()
+	startDate, finishDate := calendar.Start(), tt.FinishDate()
+	minDays := calendar.WorkDaysUntil(finishDate)
 	resp := payload.ScheduleAssignmentResponse{
-		Assignments:          assignments,
+		Strategy:             scheduler.Name(),
+		Makespan:             tt.Makespan(),
+		StartDate:            &startDate,
+		FinishDate:           &finishDate,
+		Assignments:          tt.Assignments(),
+		CriticalPath:         criticalPath,
+		CriticalPathHours:    criticalPathHours,
+		LateTasks:            tt.LateTasks(),
+		Unassignable:         tt.Unassigned(open),
+		Reschedule:           rescheduled,
+		ConsideredTasks:      len(open),
 		MinWeek:              uint(totalWeeks),
 		TotalWorkDay:         uint(minDays),
-		TotalElapsedWorkHour: uint(totalElapsedWorkHour),
+		TotalElapsedWorkHour: uint(tt.TotalHours()),
+	}
+	if req.Timeline {
+		resp.Timeline = tt.Timeline()
+	}
+
+	if len(resp.Unassignable) > 0 {
+		s.logger.Warn("%v tasks cannot be assigned to any developer", len(resp.Unassignable))
+	}
+	if rescheduled != nil {
+		s.logger.Trace("Rescheduled from plan id=%v, elapsedWeeks=%v, frozen=%v tasks, moved=%v tasks", base.ID, elapsed, len(rescheduled.FrozenTasks), len(rescheduled.MovedTasks))
+	}
+	if len(resp.LateTasks) > 0 {
+		s.logger.Warn("%v tasks are projected to miss their due date", len(resp.LateTasks))
 	}
 
-	s.logger.Trace("Assignments scheduled successfully with minWeek=%v weeks (%v days), totalElapsedWorkHour=%v hours", totalWeeks, minDays, totalElapsedWorkHour)
+	s.logger.Trace("Assignments scheduled successfully with strategy=%v, minWeek=%v weeks (%v days), finishDate=%v, makespan=%v hours, totalElapsedWorkHour=%v hours", scheduler.Name(), totalWeeks, minDays, finishDate.Format(dateLayout), resp.Makespan, resp.TotalElapsedWorkHour)
 	return resp, nil
 }
 
-// fetchTasks retrieves the list of tasks from the repository.
+// skillMatchingFromRequest returns whether skills are matched softly and the
+// penalty per missing skill. Values missing from the request are taken from
+// the application configuration.
+func skillMatchingFromRequest(req payload.ScheduleAssignmentRequest) (bool, float64) {
+	conf := config.GetApp()
+
+	match := conf.SkillMatch
+	if req.SkillMatch != "" {
+		match = req.SkillMatch
+	}
+	penalty := conf.SkillPenalty
+	if req.SkillPenalty > 0 {
+		penalty = req.SkillPenalty
+	}
+	return strings.EqualFold(match, "soft"), math.Max(penalty, 1)
+}
+
+// splitModeFromRequest returns how tasks larger than a week are split. A
+// missing value is taken from the application configuration.
+func splitModeFromRequest(req payload.ScheduleAssignmentRequest) (SplitMode, error) {
+	name := config.GetApp().TaskSplit
+	if req.Split != "" {
+		name = req.Split
+	}
+	mode, ok := splitModes[strings.ToLower(name)]
+	if !ok {
+		return SplitNone, fmt.Errorf("%w: %q (available: none, weeks, developers)", ErrInvalidSplitMode, name)
+	}
+	return mode, nil
+}
+
+// openTasks returns the tasks that are neither done nor cancelled.
+func openTasks(tasks []payload.Task) []payload.Task {
+	open := make([]payload.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if !task.Status.Closed() {
+			open = append(open, task)
+		}
+	}
+	return open
+}
+
+// fetchTasks retrieves all tasks from the repository, however many there are.
 func (s *service) fetchTasks(ctx context.Context) ([]payload.Task, error) {
-	tasksResp, err := s.repository.ListTasks(ctx, payload.ListTasksRequest{})
+	var tasks []payload.Task
+	err := s.repository.IterateTasks(ctx, func(batch []payload.Task) error {
+		tasks = append(tasks, batch...)
+		return nil
+	})
 	if err != nil {
 		s.logger.Error("Failed to list tasks: error=%v", err)
 		return nil, err
 	}
-	return tasksResp.Tasks, nil
+	return tasks, nil
 }
 
 // fetchDevelopers retrieves the list of developers from the repository.
@@ -94,88 +210,12 @@ func (s *service) fetchDevelopers(ctx context.Context) ([]payload.Developer, err
 	return developersResp.Developers, nil
 }
 
-// assignTasksToDevelopers assigns tasks to developers based on their capacity and workload.
-func (s *service) assignTasksToDevelopers(remainingTasks []payload.Task, developers []payload.Developer, weeklyWorkHours int, totalElapsedWorkHour float64) (payload.Assignment, []payload.Task, float64) {
-	assignment := payload.Assignment{
-		DeveloperTasks: []payload.DeveloperTaskAssignment{},
-	}
-	newRemainingTasks := []payload.Task{}
-
-	// Initialize developer workloads
-	developerWorkloads := make(map[uint]float64)
-	for _, dev := range developers {
-		developerWorkloads[dev.ID] = 0
-	}
-
-	// Assign tasks to developers
-	for _, task := range remainingTasks {
-		assigned := false
-		for _, dev := range developers {
-			// Calculate the effective task duration based on developer's capacity
-			effectiveTaskDuration := float64(task.Difficulty) / float64(dev.Capacity)
-
-			// Check if the developer can handle the task within weekly limits
-			if developerWorkloads[dev.ID]+effectiveTaskDuration <= float64(weeklyWorkHours) {
-				// Assign task to developer
-				assignment = s.assignTaskToDeveloper(dev, task, assignment)
-				developerWorkloads[dev.ID] += effectiveTaskDuration
-				totalElapsedWorkHour += effectiveTaskDuration
-				assigned = true
-				break
-			}
-		}
-
-		// If the task could not be assigned, add it to the remaining tasks for the next week
-		if !assigned {
-			newRemainingTasks = append(newRemainingTasks, task)
-		}
-	}
-
-	// Rebalance tasks between developers to ensure fair workload distribution
-	s.rebalanceWorkload(assignment, developerWorkloads)
-
-	return assignment, newRemainingTasks, totalElapsedWorkHour
-}
-
-// assignTaskToDeveloper assigns a task to a developer and returns the updated assignment.
-func (s *service) assignTaskToDeveloper(dev payload.Developer, task payload.Task, assignment payload.Assignment) payload.Assignment {
-	// Check if the task is already assigned to the developer
-	for i := range assignment.DeveloperTasks {
-		if assignment.DeveloperTasks[i].Developer.ID == dev.ID {
-			assignment.DeveloperTasks[i].Tasks = append(assignment.DeveloperTasks[i].Tasks, task)
-			return assignment
-		}
-	}
-
-	// If not, create a new assignment for the developer
-	assignment.DeveloperTasks = append(assignment.DeveloperTasks, payload.DeveloperTaskAssignment{
-		Developer: dev,
-		Tasks:     []payload.Task{task},
-	})
-	return assignment
-}
-
-// rebalanceWorkload ensures the tasks are fairly distributed among developers.
-func (s *service) rebalanceWorkload(assignment payload.Assignment, developerWorkloads map[uint]float64) {
-	// Sort developer tasks by the number of tasks assigned
-	sort.Slice(assignment.DeveloperTasks, func(i, j int) bool {
-		return len(assignment.DeveloperTasks[i].Tasks) > len(assignment.DeveloperTasks[j].Tasks)
-	})
-
-	// Rebalance tasks to developers with less workload
-	for i := 0; i < len(assignment.DeveloperTasks); i++ {
-		for j := 0; j < len(assignment.DeveloperTasks); j++ {
-			if len(assignment.DeveloperTasks[i].Tasks) > 1 && len(assignment.DeveloperTasks[j].Tasks) == 0 {
-				// Move a task from an overloaded developer to an underloaded one
-				taskToMove := assignment.DeveloperTasks[i].Tasks[len(assignment.DeveloperTasks[i].Tasks)-1]
-				assignment.DeveloperTasks[j].Tasks = append(assignment.DeveloperTasks[j].Tasks, taskToMove)
-				assignment.DeveloperTasks[i].Tasks = assignment.DeveloperTasks[i].Tasks[:len(assignment.DeveloperTasks[i].Tasks)-1]
-
-				// Update workload of both developers
-				developerWorkloads[assignment.DeveloperTasks[j].Developer.ID] += float64(taskToMove.Difficulty) / float64(assignment.DeveloperTasks[j].Developer.Capacity)
-				developerWorkloads[assignment.DeveloperTasks[i].Developer.ID] -= float64(taskToMove.Difficulty) / float64(assignment.DeveloperTasks[i].Developer.Capacity)
-				break
-			}
-		}
+// fetchAbsences retrieves the absences of all developers from the repository.
+func (s *service) fetchAbsences(ctx context.Context) ([]payload.Absence, error) {
+	absencesResp, err := s.repository.ListAbsences(ctx, payload.ListAbsencesRequest{})
+	if err != nil {
+		s.logger.Error("Failed to list absences: error=%v", err)
+		return nil, err
 	}
+	return absencesResp.Absences, nil
 }
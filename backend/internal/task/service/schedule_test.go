@@ -0,0 +1,618 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/config"
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+	"github.com/mehmetali10/task-planner/internal/task/service"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepo serves fixed tasks and developers without a database.
+type fakeRepo struct {
+	repository.Repository
+	tasks        []payload.Task
+	developers   []payload.Developer
+	absences     []payload.Absence
+	dependencies []payload.TaskDependency
+	plans        []payload.Plan
+}
+
+// IterateTasks serves the tasks in batches of 500, as the repository does.
+func (f *fakeRepo) IterateTasks(ctx context.Context, fn func(tasks []payload.Task) error) error {
+	for start := 0; start < len(f.tasks); start += 500 {
+		if err := fn(append([]payload.Task(nil), f.tasks[start:min(start+500, len(f.tasks))]...)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeRepo) ListDevelopers(ctx context.Context, req payload.ListDevelopersRequest) (payload.ListDevelopersResponse, error) {
+	return payload.ListDevelopersResponse{Developers: append([]payload.Developer(nil), f.developers...)}, nil
+}
+
+func (f *fakeRepo) ListAbsences(ctx context.Context, req payload.ListAbsencesRequest) (payload.ListAbsencesResponse, error) {
+	return payload.ListAbsencesResponse{Absences: f.absences}, nil
+}
+
+func (f *fakeRepo) ListTaskDependencies(ctx context.Context, req payload.ListTaskDependenciesRequest) (payload.ListTaskDependenciesResponse, error) {
+	return payload.ListTaskDependenciesResponse{Dependencies: f.dependencies}, nil
+}
+
+func (f *fakeRepo) CreateTaskDependency(ctx context.Context, req payload.CreateTaskDependencyRequest) (payload.CreateTaskDependencyResponse, error) {
+	f.dependencies = append(f.dependencies, payload.TaskDependency{
+		ID:          uint(len(f.dependencies) + 1),
+		TaskID:      req.TaskID,
+		DependsOnID: req.DependsOnID,
+	})
+	return payload.CreateTaskDependencyResponse{ID: uint(len(f.dependencies))}, nil
+}
+
+func newScheduleService(t *testing.T, repo repository.Repository) service.Service {
+	t.Helper()
+	require.NoError(t, config.LoadConfig())
+	return service.NewService(repo)
+}
+
+func seedDevelopers() []payload.Developer {
+	return []payload.Developer{
+		{ID: 1, FirstName: "DEV1", Capacity: 1},
+		{ID: 2, FirstName: "DEV2", Capacity: 2},
+		{ID: 3, FirstName: "DEV3", Capacity: 3},
+	}
+}
+
+func seedTasks(n int) []payload.Task {
+	tasks := make([]payload.Task, 0, n)
+	for i := 1; i <= n; i++ {
+		tasks = append(tasks, payload.Task{
+			ID:         uint(i),
+			ExternalID: uint(i),
+			Name:       "Task",
+			Duration:   i%7 + 1,
+			Difficulty: i%10 + 1,
+			Provider:   "test",
+		})
+	}
+	return tasks
+}
+
+func TestScheduleAssignments(t *testing.T) {
+	t.Run("Strategies", func(t *testing.T) {
+		repo := &fakeRepo{tasks: seedTasks(60), developers: seedDevelopers()}
+		svc := newScheduleService(t, repo)
+
+		for _, strategy := range service.SchedulerNames() {
+			t.Run(strategy, func(t *testing.T) {
+				resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{Strategy: strategy})
+				require.NoError(t, err)
+				require.Equal(t, strategy, resp.Strategy)
+				require.Greater(t, resp.Makespan, 0.0)
+
+				// Every task is scheduled exactly once and no week is overbooked
+				seen := map[uint]bool{}
+				for _, assignment := range resp.Assignments {
+					for _, devTasks := range assignment.DeveloperTasks {
+						hours := 0.0
+						for _, task := range devTasks.Tasks {
+							require.False(t, seen[task.ID], "task %d scheduled twice", task.ID)
+							seen[task.ID] = true
+							hours += float64(task.Difficulty) / float64(devTasks.Developer.Capacity)
+						}
+						require.LessOrEqual(t, hours, 45.0)
+					}
+				}
+				require.Len(t, seen, len(repo.tasks))
+			})
+		}
+	})
+
+	t.Run("BranchAndBoundIsNotWorseThanLPT", func(t *testing.T) {
+		repo := &fakeRepo{tasks: seedTasks(8), developers: seedDevelopers()}
+		svc := newScheduleService(t, repo)
+
+		lpt, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{Strategy: "lpt"})
+		require.NoError(t, err)
+		exact, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{Strategy: "branch-and-bound"})
+		require.NoError(t, err)
+
+		require.LessOrEqual(t, exact.Makespan, lpt.Makespan)
+	})
+
+	t.Run("FullBacklog", func(t *testing.T) {
+		// More tasks than a page of the task list holds
+		repo := &fakeRepo{tasks: seedTasks(1200), developers: seedDevelopers()}
+		repo.tasks[0].Status = payload.StatusDone
+		svc := newScheduleService(t, repo)
+
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{Strategy: "lpt"})
+		require.NoError(t, err)
+		require.Equal(t, 1199, resp.ConsideredTasks)
+
+		scheduled := 0
+		for _, assignment := range resp.Assignments {
+			for _, devTasks := range assignment.DeveloperTasks {
+				scheduled += len(devTasks.Tasks)
+			}
+		}
+		require.Equal(t, 1199, scheduled)
+	})
+
+	t.Run("DefaultStrategy", func(t *testing.T) {
+		repo := &fakeRepo{tasks: seedTasks(5), developers: seedDevelopers()}
+		svc := newScheduleService(t, repo)
+
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{})
+		require.NoError(t, err)
+		require.Equal(t, service.DefaultStrategy, resp.Strategy)
+	})
+
+	t.Run("GreedyRebalancesWorkload", func(t *testing.T) {
+		// The first developer has room for every task, the others take over
+		// the last tasks of the busiest one instead of staying idle
+		repo := &fakeRepo{tasks: seedTasks(4), developers: seedDevelopers()}
+		svc := newScheduleService(t, repo)
+
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{Strategy: "greedy"})
+		require.NoError(t, err)
+		require.Len(t, resp.Assignments, 1)
+		tasks := map[uint]int{}
+		for _, devTasks := range resp.Assignments[0].DeveloperTasks {
+			tasks[devTasks.Developer.ID] = len(devTasks.Tasks)
+		}
+		require.Equal(t, map[uint]int{1: 2, 2: 1, 3: 1}, tasks)
+	})
+
+	t.Run("WorkingCalendar", func(t *testing.T) {
+		tasks := make([]payload.Task, 0, 6)
+		for i := 1; i <= 6; i++ {
+			tasks = append(tasks, payload.Task{ID: uint(i), Name: "Task", Duration: 1, Difficulty: 10})
+		}
+		repo := &fakeRepo{tasks: tasks, developers: []payload.Developer{{ID: 1, Capacity: 1}}}
+		svc := newScheduleService(t, repo)
+
+		tests := []struct {
+			name       string
+			holidays   []string
+			firstWeek  int
+			finishDate string
+			workDays   uint
+		}{
+			{name: "NoHolidays", firstWeek: 4, finishDate: "2026-10-28", workDays: 8},
+			{name: "HolidayInFirstWeek", holidays: []string{"2026-10-20"}, firstWeek: 3, finishDate: "2026-10-29", workDays: 8},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{
+					HoursPerDay: 9,
+					WorkDays:    []string{"Mon", "Tue", "Wed", "Thu", "Fri"},
+					Holidays:    tt.holidays,
+					StartDate:   "2026-10-19",
+				})
+				require.NoError(t, err)
+				require.Len(t, resp.Assignments, 2)
+				require.Len(t, resp.Assignments[0].DeveloperTasks[0].Tasks, tt.firstWeek)
+				require.Equal(t, "2026-10-26", resp.Assignments[1].StartDate.Format("2006-01-02"))
+				require.Equal(t, tt.finishDate, resp.FinishDate.Format("2006-01-02"))
+				require.Equal(t, tt.workDays, resp.TotalWorkDay)
+			})
+		}
+	})
+
+	t.Run("Availability", func(t *testing.T) {
+		tasks := make([]payload.Task, 0, 8)
+		for i := 1; i <= 8; i++ {
+			tasks = append(tasks, payload.Task{ID: uint(i), Name: "Task", Duration: 1, Difficulty: 9})
+		}
+		developers := []payload.Developer{
+			{ID: 1, Capacity: 1},
+			{ID: 2, Capacity: 1, PartTimePercent: 50},
+		}
+		vacation := payload.Absence{
+			DeveloperID: 1,
+			StartDate:   time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			EndDate:     time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC),
+		}
+		repo := &fakeRepo{tasks: tasks, developers: developers, absences: []payload.Absence{vacation}}
+		svc := newScheduleService(t, repo)
+
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{StartDate: "2026-10-19"})
+		require.NoError(t, err)
+
+		// The developer on vacation gets nothing in the first week, the part-time developer gets half a week
+		firstWeek := resp.Assignments[0].DeveloperTasks
+		require.Len(t, firstWeek, 1)
+		require.Equal(t, uint(2), firstWeek[0].Developer.ID)
+		require.Len(t, firstWeek[0].Tasks, 2)
+
+		for _, assignment := range resp.Assignments[1:] {
+			for _, devTasks := range assignment.DeveloperTasks {
+				if devTasks.Developer.ID == 1 {
+					require.Len(t, devTasks.Tasks, 5)
+				}
+			}
+		}
+	})
+
+	t.Run("Timeline", func(t *testing.T) {
+		tasks := []payload.Task{
+			{ID: 1, Name: "Task", Duration: 4, Difficulty: 6},
+			{ID: 2, Name: "Task", Duration: 3, Difficulty: 6},
+			{ID: 3, Name: "Task", Duration: 2, Difficulty: 9},
+			{ID: 4, Name: "Task", Duration: 1, Difficulty: 6},
+		}
+		repo := &fakeRepo{tasks: tasks, developers: []payload.Developer{{ID: 1, Capacity: 1}}}
+		svc := newScheduleService(t, repo)
+
+		dayStart := 9.0
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{
+			Timeline:     true,
+			HoursPerDay:  9,
+			DayStartHour: &dayStart,
+			StartDate:    "2026-10-19",
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Timeline, 1)
+
+		expected := [][2]string{
+			{"2026-10-19 09:00", "2026-10-19 15:00"},
+			{"2026-10-19 15:00", "2026-10-20 12:00"},
+			{"2026-10-20 12:00", "2026-10-21 12:00"},
+			{"2026-10-21 12:00", "2026-10-21 18:00"},
+		}
+		slots := resp.Timeline[0].Slots
+		require.Len(t, slots, len(expected))
+		for i, slot := range slots {
+			require.Equal(t, uint(i+1), slot.Task.ID)
+			require.Equal(t, expected[i][0], slot.Start.Format("2006-01-02 15:04"))
+			require.Equal(t, expected[i][1], slot.End.Format("2006-01-02 15:04"))
+		}
+
+		// Work may begin at midnight
+		midnight := 0.0
+		resp, err = svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{
+			Timeline:     true,
+			HoursPerDay:  9,
+			DayStartHour: &midnight,
+			StartDate:    "2026-10-19",
+		})
+		require.NoError(t, err)
+		require.Equal(t, "2026-10-19 00:00", resp.Timeline[0].Slots[0].Start.Format("2006-01-02 15:04"))
+		require.Equal(t, "2026-10-19 06:00", resp.Timeline[0].Slots[0].End.Format("2006-01-02 15:04"))
+
+		// Without the flag the timeline is omitted
+		resp, err = svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{StartDate: "2026-10-19"})
+		require.NoError(t, err)
+		require.Nil(t, resp.Timeline)
+	})
+
+	t.Run("Dependencies", func(t *testing.T) {
+		tasks := []payload.Task{
+			{ID: 1, Name: "Task", Duration: 1, Difficulty: 9},
+			{ID: 2, Name: "Task", Duration: 1, Difficulty: 9},
+			{ID: 3, Name: "Task", Duration: 9, Difficulty: 9},
+			{ID: 4, Name: "Task", Duration: 1, Difficulty: 9},
+		}
+		// 1 -> 2 -> 4 is a chain, 3 is independent
+		dependencies := []payload.TaskDependency{
+			{TaskID: 2, DependsOnID: 1},
+			{TaskID: 4, DependsOnID: 2},
+		}
+		developers := []payload.Developer{{ID: 1, Capacity: 1}, {ID: 2, Capacity: 1}}
+		repo := &fakeRepo{tasks: tasks, developers: developers, dependencies: dependencies}
+		svc := newScheduleService(t, repo)
+
+		for _, strategy := range service.SchedulerNames() {
+			t.Run(strategy, func(t *testing.T) {
+				resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{
+					Strategy:    strategy,
+					Timeline:    true,
+					HoursPerDay: 9,
+					StartDate:   "2026-10-19",
+				})
+				require.NoError(t, err)
+
+				// Every task starts once the task it depends on has ended
+				start, end := map[uint]time.Time{}, map[uint]time.Time{}
+				for _, developer := range resp.Timeline {
+					for _, slot := range developer.Slots {
+						start[slot.Task.ID], end[slot.Task.ID] = slot.Start, slot.End
+					}
+				}
+				require.Len(t, start, len(tasks))
+				for _, d := range dependencies {
+					require.False(t, start[d.TaskID].Before(end[d.DependsOnID]), "task %d starts before task %d ends", d.TaskID, d.DependsOnID)
+				}
+
+				require.Len(t, resp.CriticalPath, 3)
+				for i, id := range []uint{1, 2, 4} {
+					require.Equal(t, id, resp.CriticalPath[i].ID)
+				}
+				require.InDelta(t, 27.0, resp.CriticalPathHours, 1e-9)
+
+				// Strategies that compare finish times keep the chain on its own developer
+				if strategy == "lpt" || strategy == "branch-and-bound" {
+					require.InDelta(t, 27.0, resp.Makespan, 1e-9)
+				}
+			})
+		}
+	})
+
+	t.Run("WideDependencyGraph", func(t *testing.T) {
+		// Layers of diamonds, every task depends on all tasks of the layer
+		// before, so there are 10^20 chains through the graph
+		const layers, width = 20, 10
+		tasks := make([]payload.Task, 0, layers*width)
+		var dependencies []payload.TaskDependency
+		for layer := 0; layer < layers; layer++ {
+			for k := 1; k <= width; k++ {
+				id := uint(layer*width + k)
+				tasks = append(tasks, payload.Task{ID: id, Name: "Task", Duration: 1, Difficulty: 1})
+				for prev := 1; layer > 0 && prev <= width; prev++ {
+					dependencies = append(dependencies, payload.TaskDependency{TaskID: id, DependsOnID: uint((layer-1)*width + prev)})
+				}
+			}
+		}
+		repo := &fakeRepo{tasks: tasks, developers: seedDevelopers(), dependencies: dependencies}
+		svc := newScheduleService(t, repo)
+
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{
+			Strategy:    "lpt",
+			Timeline:    true,
+			HoursPerDay: 9,
+			StartDate:   "2026-10-19",
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Unassignable)
+		require.Len(t, resp.CriticalPath, layers)
+
+		start, end := map[uint]time.Time{}, map[uint]time.Time{}
+		for _, developer := range resp.Timeline {
+			for _, slot := range developer.Slots {
+				start[slot.Task.ID], end[slot.Task.ID] = slot.Start, slot.End
+			}
+		}
+		require.Len(t, start, len(tasks))
+		for _, d := range dependencies {
+			require.False(t, start[d.TaskID].Before(end[d.DependsOnID]), "task %d starts before task %d ends", d.TaskID, d.DependsOnID)
+		}
+	})
+
+	t.Run("PrioritiesAndDeadlines", func(t *testing.T) {
+		date := func(day int) *time.Time {
+			d := time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)
+			return &d
+		}
+		tasks := []payload.Task{
+			{ID: 1, Name: "Task", Duration: 5, Difficulty: 9},
+			{ID: 2, Name: "Task", Duration: 1, Difficulty: 9, DueDate: date(20)},
+			{ID: 3, Name: "Task", Duration: 1, Difficulty: 9, Priority: 1},
+			{ID: 4, Name: "Task", Duration: 1, Difficulty: 9, DueDate: date(19)},
+		}
+		repo := &fakeRepo{tasks: tasks, developers: []payload.Developer{{ID: 1, Capacity: 1}}}
+		svc := newScheduleService(t, repo)
+
+		for _, strategy := range service.SchedulerNames() {
+			t.Run(strategy, func(t *testing.T) {
+				resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{
+					Strategy:    strategy,
+					Timeline:    true,
+					HoursPerDay: 9,
+					StartDate:   "2026-10-19",
+				})
+				require.NoError(t, err)
+
+				// Higher priority first, then the earliest due date
+				slots := resp.Timeline[0].Slots
+				require.Len(t, slots, len(tasks))
+				for i, id := range []uint{3, 4, 2, 1} {
+					require.Equal(t, id, slots[i].Task.ID)
+				}
+
+				require.Len(t, resp.LateTasks, 2)
+				require.Equal(t, uint(4), resp.LateTasks[0].Task.ID)
+				require.Equal(t, "2026-10-20", resp.LateTasks[0].End.Format("2006-01-02"))
+				require.Equal(t, 1, resp.LateTasks[0].DaysLate)
+				require.Equal(t, uint(2), resp.LateTasks[1].Task.ID)
+				require.Equal(t, 1, resp.LateTasks[1].DaysLate)
+			})
+		}
+	})
+
+	t.Run("Skills", func(t *testing.T) {
+		tasks := []payload.Task{
+			{ID: 1, Name: "Task", Duration: 1, Difficulty: 9, Skills: payload.NewSkills("backend")},
+			{ID: 2, Name: "Task", Duration: 1, Difficulty: 9, Skills: payload.NewSkills("frontend")},
+			{ID: 3, Name: "Task", Duration: 1, Difficulty: 9, Skills: payload.NewSkills("infra")},
+			{ID: 4, Name: "Task", Duration: 1, Difficulty: 9, Skills: payload.NewSkills("backend", "frontend")},
+			{ID: 5, Name: "Task", Duration: 1, Difficulty: 9},
+		}
+		developers := []payload.Developer{
+			{ID: 1, Capacity: 1, Skills: payload.NewSkills("Backend")},
+			{ID: 2, Capacity: 3, Skills: payload.NewSkills("frontend")},
+		}
+		repo := &fakeRepo{tasks: tasks, developers: developers}
+		svc := newScheduleService(t, repo)
+
+		for _, strategy := range service.SchedulerNames() {
+			t.Run(strategy, func(t *testing.T) {
+				resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{Strategy: strategy})
+				require.NoError(t, err)
+
+				// Tasks only go to developers holding all their skills
+				for _, assignment := range resp.Assignments {
+					for _, devTasks := range assignment.DeveloperTasks {
+						for _, task := range devTasks.Tasks {
+							require.Zero(t, devTasks.Developer.Skills.Missing(task.Skills), "task %d given to developer %d", task.ID, devTasks.Developer.ID)
+						}
+					}
+				}
+
+				unassignable := []uint{}
+				for _, entry := range resp.Unassignable {
+					require.Equal(t, service.ReasonMissingSkills, entry.Reason)
+					unassignable = append(unassignable, entry.Task.ID)
+				}
+				require.ElementsMatch(t, []uint{3, 4}, unassignable)
+			})
+		}
+
+		t.Run("Soft", func(t *testing.T) {
+			resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{
+				Strategy:     "lpt",
+				Timeline:     true,
+				SkillMatch:   "soft",
+				SkillPenalty: 2,
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp.Unassignable)
+
+			// Missing skills multiply the effort by the penalty
+			for _, developer := range resp.Timeline {
+				for _, slot := range developer.Slots {
+					expected := float64(slot.Task.Difficulty) / float64(developer.Developer.Capacity)
+					for i := 0; i < developer.Developer.Skills.Missing(slot.Task.Skills); i++ {
+						expected *= 2
+					}
+					require.InDelta(t, expected, slot.Hours, 1e-9)
+				}
+			}
+		})
+	})
+
+	t.Run("Splitting", func(t *testing.T) {
+		tasks := []payload.Task{
+			{ID: 1, Name: "Task", Duration: 1, Difficulty: 9},
+			{ID: 2, Name: "Task", Duration: 1, Difficulty: 2},
+		}
+		developers := []payload.Developer{{ID: 1, Capacity: 1}, {ID: 2, Capacity: 1}}
+		repo := &fakeRepo{tasks: tasks, developers: developers, dependencies: []payload.TaskDependency{{TaskID: 2, DependsOnID: 1}}}
+		svc := newScheduleService(t, repo)
+
+		// Five hour weeks are too short for the first task
+		req := payload.ScheduleAssignmentRequest{HoursPerDay: 1, StartDate: "2026-10-19", Timeline: true}
+
+		t.Run("Weeks", func(t *testing.T) {
+			for _, strategy := range service.SchedulerNames() {
+				req := req
+				req.Strategy, req.Split = strategy, "weeks"
+				resp, err := svc.ScheduleAssignments(context.Background(), req)
+				require.NoError(t, err)
+				require.Empty(t, resp.Unassignable)
+
+				// The task is split over two weeks of the same developer, the dependent
+				// task fits into the week after
+				var chunks []payload.TaskChunk
+				for _, assignment := range resp.Assignments {
+					for _, devTasks := range assignment.DeveloperTasks {
+						chunks = append(chunks, devTasks.Chunks...)
+					}
+				}
+				require.Len(t, chunks, 2, strategy)
+				require.Equal(t, 1, chunks[0].Part)
+				require.Equal(t, 2, chunks[0].Parts)
+				require.InDelta(t, 5.0, chunks[0].Hours, 1e-9)
+				require.InDelta(t, 4.0, chunks[1].Hours, 1e-9)
+				require.InDelta(t, 12.0, resp.Makespan, 1e-9, strategy)
+			}
+		})
+
+		t.Run("Developers", func(t *testing.T) {
+			req := req
+			req.Strategy, req.Split = "lpt", "developers"
+			resp, err := svc.ScheduleAssignments(context.Background(), req)
+			require.NoError(t, err)
+			require.Empty(t, resp.Unassignable)
+
+			// Both developers work on the task in the first week
+			require.Len(t, resp.Assignments[0].DeveloperTasks, 2)
+			require.InDelta(t, 7.0, resp.Makespan, 1e-9)
+		})
+
+		t.Run("None", func(t *testing.T) {
+			req := req
+			req.Split = "none"
+			resp, err := svc.ScheduleAssignments(context.Background(), req)
+			require.NoError(t, err)
+			require.Len(t, resp.Unassignable, 2)
+			require.Equal(t, service.ReasonExceedsCapacity, resp.Unassignable[0].Reason)
+			require.Equal(t, service.ReasonBlocked, resp.Unassignable[1].Reason)
+		})
+
+		t.Run("InvalidMode", func(t *testing.T) {
+			req := req
+			req.Split = "halves"
+			_, err := svc.ScheduleAssignments(context.Background(), req)
+			require.ErrorIs(t, err, service.ErrInvalidSplitMode)
+		})
+	})
+
+	t.Run("Horizon", func(t *testing.T) {
+		t.Setenv("MAX_PLAN_WEEKS", "4")
+		absence := payload.Absence{
+			DeveloperID: 1,
+			StartDate:   time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+			EndDate:     time.Date(2027, 12, 31, 0, 0, 0, 0, time.UTC),
+		}
+		repo := &fakeRepo{tasks: seedTasks(3), developers: []payload.Developer{{ID: 1, Capacity: 1}}, absences: []payload.Absence{absence}}
+		svc := newScheduleService(t, repo)
+
+		// Nobody is available within the horizon, the scheduler gives up instead of searching forever
+		for _, strategy := range service.SchedulerNames() {
+			resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{Strategy: strategy, StartDate: "2026-10-19"})
+			require.NoError(t, err)
+			require.Len(t, resp.Unassignable, 3)
+			for _, entry := range resp.Unassignable {
+				require.Equal(t, service.ReasonBeyondHorizon, entry.Reason)
+			}
+		}
+	})
+
+	t.Run("DependencyCycle", func(t *testing.T) {
+		repo := &fakeRepo{
+			tasks:        seedTasks(3),
+			developers:   seedDevelopers(),
+			dependencies: []payload.TaskDependency{{TaskID: 2, DependsOnID: 1}, {TaskID: 3, DependsOnID: 2}},
+		}
+		svc := newScheduleService(t, repo)
+
+		_, err := svc.CreateTaskDependency(context.Background(), payload.CreateTaskDependencyRequest{TaskID: 1, DependsOnID: 3})
+		require.ErrorIs(t, err, service.ErrDependencyCycle)
+		_, err = svc.CreateTaskDependency(context.Background(), payload.CreateTaskDependencyRequest{TaskID: 3, DependsOnID: 1})
+		require.NoError(t, err)
+
+		// A cycle that made it into the database is reported instead of scheduled
+		repo.dependencies = append(repo.dependencies, payload.TaskDependency{TaskID: 1, DependsOnID: 3})
+		_, err = svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{})
+		require.ErrorIs(t, err, service.ErrDependencyCycle)
+	})
+
+	t.Run("InvalidCalendar", func(t *testing.T) {
+		repo := &fakeRepo{tasks: seedTasks(5), developers: seedDevelopers()}
+		svc := newScheduleService(t, repo)
+
+		_, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{WorkDays: []string{"Funday"}})
+		require.ErrorIs(t, err, service.ErrInvalidCalendar)
+
+		_, err = svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{StartDate: "19.10.2026"})
+		require.ErrorIs(t, err, service.ErrInvalidCalendar)
+
+		lateStart := 23.5
+		_, err = svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{HoursPerDay: 0.5, DayStartHour: &lateStart})
+		require.ErrorIs(t, err, service.ErrInvalidCalendar)
+	})
+
+	t.Run("UnknownStrategy", func(t *testing.T) {
+		repo := &fakeRepo{tasks: seedTasks(5), developers: seedDevelopers()}
+		svc := newScheduleService(t, repo)
+
+		_, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{Strategy: "random"})
+		require.ErrorIs(t, err, service.ErrUnknownStrategy)
+	})
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// DefaultStrategy is the scheduler used when a request does not name one.
+const DefaultStrategy = "greedy"
+
+// ErrUnknownStrategy is returned when a request selects a scheduler that is not registered.
+var ErrUnknownStrategy = errors.New("unknown scheduling strategy")
+
+// Scheduler distributes tasks over the weekly capacity of a team.
+//
+// Implementations only decide which developer works on a task and in which
+// week; the Timetable keeps track of the booked hours and produces the response.
+type Scheduler interface {
+	// Name returns the identifier used to select the scheduler.
+	Name() string
+	// Schedule assigns every task it can place to the timetable.
+	Schedule(tt *Timetable, tasks []payload.Task)
+}
+
+var (
+	schedulersMu sync.RWMutex
+	schedulers   = map[string]Scheduler{}
+)
+
+// RegisterScheduler makes a scheduler selectable by its name.
+// Registering a scheduler with an existing name replaces the previous one.
+func RegisterScheduler(s Scheduler) {
+	schedulersMu.Lock()
+	defer schedulersMu.Unlock()
+	schedulers[s.Name()] = s
+}
+
+// SchedulerNames returns the names of all registered schedulers in alphabetical order.
+func SchedulerNames() []string {
+	schedulersMu.RLock()
+	defer schedulersMu.RUnlock()
+
+	names := make([]string, 0, len(schedulers))
+	for name := range schedulers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// getScheduler looks up a registered scheduler, falling back to DefaultStrategy for an empty name.
+func getScheduler(name string) (Scheduler, error) {
+	if name == "" {
+		name = DefaultStrategy
+	}
+
+	schedulersMu.RLock()
+	s, ok := schedulers[name]
+	schedulersMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (available: %v)", ErrUnknownStrategy, name, SchedulerNames())
+	}
+	return s, nil
+}
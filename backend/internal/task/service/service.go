@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"github.com/mehmetali10/task-planner/pkg/log"
 
@@ -12,11 +13,39 @@ import (
 
 type Service interface {
 	CreateTask(ctx context.Context, req payload.CreateTaskRequest) (payload.CreateTaskResponse, error)
+	CreateTasks(ctx context.Context, tasks []payload.CreateTaskRequest) (payload.BulkCreateTasksResponse, error)
 	ListTasks(ctx context.Context, req payload.ListTasksRequest) (payload.ListTasksResponse, error)
+	SearchTasks(ctx context.Context, req payload.SearchTasksRequest) (payload.SearchTasksResponse, error)
+	GetTask(ctx context.Context, req payload.GetTaskRequest) (payload.Task, error)
+	UpdateTask(ctx context.Context, req payload.UpdateTaskRequest) (payload.Task, error)
+	PatchTask(ctx context.Context, req payload.PatchTaskRequest) (payload.Task, error)
+	DeleteTask(ctx context.Context, req payload.DeleteTaskRequest) error
+	ListTaskEvents(ctx context.Context, req payload.ListTaskEventsRequest) (payload.ListTaskEventsResponse, error)
+	UpdateTaskStatus(ctx context.Context, req payload.UpdateTaskStatusRequest) (payload.Task, error)
+
+	CreateTaskDependency(ctx context.Context, req payload.CreateTaskDependencyRequest) (payload.CreateTaskDependencyResponse, error)
+	ListTaskDependencies(ctx context.Context, req payload.ListTaskDependenciesRequest) (payload.ListTaskDependenciesResponse, error)
+	DeleteTaskDependency(ctx context.Context, req payload.DeleteTaskDependencyRequest) error
 
 	ScheduleAssignments(ctx context.Context, req payload.ScheduleAssignmentRequest) (payload.ScheduleAssignmentResponse, error)
 
+	CreatePlan(ctx context.Context, req payload.CreatePlanRequest) (payload.Plan, error)
+	GetPlan(ctx context.Context, req payload.GetPlanRequest) (payload.Plan, error)
+	ListPlans(ctx context.Context, req payload.ListPlansRequest) (payload.ListPlansResponse, error)
+	DiffPlans(ctx context.Context, req payload.DiffPlansRequest) (payload.DiffPlansResponse, error)
+
 	ListDevelopers(ctx context.Context, req payload.ListDevelopersRequest) (payload.ListDevelopersResponse, error)
+	CreateDeveloper(ctx context.Context, req payload.CreateDeveloperRequest) (payload.Developer, error)
+	GetDeveloper(ctx context.Context, req payload.GetDeveloperRequest) (payload.Developer, error)
+	UpdateDeveloper(ctx context.Context, req payload.UpdateDeveloperRequest) (payload.Developer, error)
+	DeleteDeveloper(ctx context.Context, req payload.DeleteDeveloperRequest) error
+	UpdateDeveloperSkills(ctx context.Context, req payload.UpdateDeveloperSkillsRequest) (payload.Developer, error)
+
+	CreateAbsence(ctx context.Context, req payload.CreateAbsenceRequest) (payload.CreateAbsenceResponse, error)
+	GetAbsence(ctx context.Context, req payload.GetAbsenceRequest) (payload.Absence, error)
+	ListAbsences(ctx context.Context, req payload.ListAbsencesRequest) (payload.ListAbsencesResponse, error)
+	UpdateAbsence(ctx context.Context, req payload.UpdateAbsenceRequest) (payload.Absence, error)
+	DeleteAbsence(ctx context.Context, req payload.DeleteAbsenceRequest) error
 }
 
 type service struct {
@@ -33,13 +62,15 @@ func NewService(repository repository.Repository) Service {
 	}
 }
 
-// CreateTask implements Service.
+// CreateTask implements Service. An existing task of the provider with the
+// same external ID is updated instead.
 func (s *service) CreateTask(ctx context.Context, req payload.CreateTaskRequest) (payload.CreateTaskResponse, error) {
 	s.logger.Trace(
 		"Creating new task externalId=%v, provider=%v",
 		req.ExternalID,
 		req.Provider,
 	)
+	req.Skills = payload.NewSkills(req.Skills...)
 	resp, err := s.repository.CreateTask(ctx, req)
 	if err != nil {
 		s.logger.Error(
@@ -51,9 +82,10 @@ func (s *service) CreateTask(ctx context.Context, req payload.CreateTaskRequest)
 		return resp, err
 	}
 	s.logger.Trace(
-		"Task created successfully externalId=%v, provider=%v",
+		"Task saved successfully externalId=%v, provider=%v, status=%v",
 		req.ExternalID,
 		req.Provider,
+		resp.Status,
 	)
 	return resp, nil
 }
@@ -70,6 +102,75 @@ func (s *service) ListDevelopers(ctx context.Context, req payload.ListDevelopers
 	return resp, nil
 }
 
+// UpdateDeveloperSkills implements Service.
+func (s *service) UpdateDeveloperSkills(ctx context.Context, req payload.UpdateDeveloperSkillsRequest) (payload.Developer, error) {
+	s.logger.Trace("Updating developer skills id=%v", req.ID)
+	req.Skills = payload.NewSkills(req.Skills...)
+	resp, err := s.repository.UpdateDeveloperSkills(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to update developer skills id=%v: error=%v", req.ID, err)
+		return resp, err
+	}
+	s.logger.Trace("Developer skills updated successfully id=%v, skills=%v", req.ID, resp.Skills)
+	return resp, nil
+}
+
+// CreateDeveloper implements Service. A part-time percentage of zero means full time.
+func (s *service) CreateDeveloper(ctx context.Context, req payload.CreateDeveloperRequest) (payload.Developer, error) {
+	s.logger.Trace("Creating developer email=%v", req.Email)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	req.Skills = payload.NewSkills(req.Skills...)
+	if req.PartTimePercent == 0 {
+		req.PartTimePercent = 100
+	}
+	resp, err := s.repository.CreateDeveloper(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to create developer email=%v: error=%v", req.Email, err)
+		return resp, err
+	}
+	s.logger.Trace("Developer created successfully id=%v", resp.ID)
+	return resp, nil
+}
+
+// GetDeveloper implements Service.
+func (s *service) GetDeveloper(ctx context.Context, req payload.GetDeveloperRequest) (payload.Developer, error) {
+	s.logger.Trace("Getting developer id=%v", req.ID)
+	resp, err := s.repository.GetDeveloper(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to get developer id=%v: error=%v", req.ID, err)
+		return resp, err
+	}
+	return resp, nil
+}
+
+// UpdateDeveloper implements Service. A part-time percentage of zero means full time.
+func (s *service) UpdateDeveloper(ctx context.Context, req payload.UpdateDeveloperRequest) (payload.Developer, error) {
+	s.logger.Trace("Updating developer id=%v", req.ID)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	req.Skills = payload.NewSkills(req.Skills...)
+	if req.PartTimePercent == 0 {
+		req.PartTimePercent = 100
+	}
+	resp, err := s.repository.UpdateDeveloper(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to update developer id=%v: error=%v", req.ID, err)
+		return resp, err
+	}
+	s.logger.Trace("Developer updated successfully id=%v", req.ID)
+	return resp, nil
+}
+
+// DeleteDeveloper implements Service.
+func (s *service) DeleteDeveloper(ctx context.Context, req payload.DeleteDeveloperRequest) error {
+	s.logger.Trace("Deleting developer id=%v", req.ID)
+	if err := s.repository.DeleteDeveloper(ctx, req); err != nil {
+		s.logger.Error("Failed to delete developer id=%v: error=%v", req.ID, err)
+		return err
+	}
+	s.logger.Trace("Developer deleted successfully id=%v", req.ID)
+	return nil
+}
+
 // ListTasks implements Service.
 func (s *service) ListTasks(ctx context.Context, req payload.ListTasksRequest) (payload.ListTasksResponse, error) {
 	s.logger.Trace("Listing tasks")
@@ -81,3 +182,78 @@ func (s *service) ListTasks(ctx context.Context, req payload.ListTasksRequest) (
 	s.logger.Trace("Tasks listed successfully")
 	return resp, nil
 }
+
+// SearchTasks implements Service.
+func (s *service) SearchTasks(ctx context.Context, req payload.SearchTasksRequest) (payload.SearchTasksResponse, error) {
+	req.Query = strings.TrimSpace(req.Query)
+	s.logger.Trace("Searching tasks query=%v", req.Query)
+	resp, err := s.repository.SearchTasks(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to search tasks query=%v: error=%v", req.Query, err)
+		return resp, err
+	}
+	s.logger.Trace("Tasks searched successfully query=%v, total=%v", req.Query, resp.Total)
+	return resp, nil
+}
+
+// GetTask implements Service.
+func (s *service) GetTask(ctx context.Context, req payload.GetTaskRequest) (payload.Task, error) {
+	s.logger.Trace("Getting task id=%v", req.ID)
+	resp, err := s.repository.GetTask(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to get task id=%v: error=%v", req.ID, err)
+		return resp, err
+	}
+	return resp, nil
+}
+
+// UpdateTask implements Service.
+func (s *service) UpdateTask(ctx context.Context, req payload.UpdateTaskRequest) (payload.Task, error) {
+	s.logger.Trace("Updating task id=%v", req.ID)
+	req.Skills = payload.NewSkills(req.Skills...)
+	resp, err := s.repository.UpdateTask(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to update task id=%v: error=%v", req.ID, err)
+		return resp, err
+	}
+	s.logger.Trace("Task updated successfully id=%v", req.ID)
+	return resp, nil
+}
+
+// PatchTask implements Service.
+func (s *service) PatchTask(ctx context.Context, req payload.PatchTaskRequest) (payload.Task, error) {
+	s.logger.Trace("Patching task id=%v", req.ID)
+	if req.Skills != nil {
+		skills := payload.NewSkills(*req.Skills...)
+		req.Skills = &skills
+	}
+	resp, err := s.repository.PatchTask(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to patch task id=%v: error=%v", req.ID, err)
+		return resp, err
+	}
+	s.logger.Trace("Task patched successfully id=%v", req.ID)
+	return resp, nil
+}
+
+// DeleteTask implements Service.
+func (s *service) DeleteTask(ctx context.Context, req payload.DeleteTaskRequest) error {
+	s.logger.Trace("Deleting task id=%v", req.ID)
+	if err := s.repository.DeleteTask(ctx, req); err != nil {
+		s.logger.Error("Failed to delete task id=%v: error=%v", req.ID, err)
+		return err
+	}
+	s.logger.Trace("Task deleted successfully id=%v", req.ID)
+	return nil
+}
+
+// ListTaskEvents implements Service.
+func (s *service) ListTaskEvents(ctx context.Context, req payload.ListTaskEventsRequest) (payload.ListTaskEventsResponse, error) {
+	s.logger.Trace("Listing events of task id=%v", req.TaskID)
+	resp, err := s.repository.ListTaskEvents(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to list events of task id=%v: error=%v", req.TaskID, err)
+		return resp, err
+	}
+	return resp, nil
+}
@@ -2,9 +2,12 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
 	postgres_repository "github.com/mehmetali10/task-planner/internal/pkg/repository/postgres"
 	"github.com/mehmetali10/task-planner/internal/pkg/testcontainer"
 	"github.com/mehmetali10/task-planner/internal/task/service"
@@ -21,9 +24,9 @@ func TestService(t *testing.T) {
 
 	t.Run("CreateTask", func(t *testing.T) {
 		tests := []struct {
-			name          string
-			input         payload.CreateTaskRequest
-			expectedError bool
+			name           string
+			input          payload.CreateTaskRequest
+			expectedStatus payload.TaskImportStatus
 		}{
 			{
 				name: "CreateTask_Success",
@@ -34,7 +37,7 @@ func TestService(t *testing.T) {
 					Difficulty: 3,
 					Provider:   "Test Provider",
 				},
-				expectedError: false,
+				expectedStatus: payload.ImportCreated,
 			},
 			{
 				name: "CreateTask_TaskAlreadyExists",
@@ -45,24 +48,35 @@ func TestService(t *testing.T) {
 					Difficulty: 3,
 					Provider:   "Test Provider",
 				},
-				expectedError: true,
+				expectedStatus: payload.ImportUnchanged,
+			},
+			{
+				name: "CreateTask_TaskChanged",
+				input: payload.CreateTaskRequest{
+					ExternalID: 123,
+					Name:       "New Task",
+					Duration:   8,
+					Difficulty: 3,
+					Provider:   "Test Provider",
+				},
+				expectedStatus: payload.ImportUpdated,
 			},
 		}
 
 		// Run each test case
+		var id uint
 		for _, tt := range tests {
 			t.Run(tt.name, func(t *testing.T) {
 				resp, err := svc.CreateTask(context.Background(), tt.input)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedStatus, resp.Status)
 
-				// Handle errors and validate response
-				if tt.expectedError {
-					require.Error(t, err)
-				} else {
-					require.NoError(t, err)
-
-					// Validate that the response ID is greater than 0
-					require.Greater(t, resp.ID, uint(0))
+				// Validate that the response ID is greater than 0 and the same for every save
+				require.Greater(t, resp.ID, uint(0))
+				if id == 0 {
+					id = resp.ID
 				}
+				require.Equal(t, id, resp.ID)
 			})
 		}
 	})
@@ -141,4 +155,56 @@ func TestService(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("TaskCRUD", func(t *testing.T) {
+		created, err := svc.CreateTask(context.Background(), payload.CreateTaskRequest{
+			ExternalID: 456,
+			Name:       "Editable Task",
+			Duration:   3,
+			Difficulty: 2,
+			Provider:   "Test Provider",
+		})
+		require.NoError(t, err)
+
+		updated, err := svc.UpdateTask(context.Background(), payload.UpdateTaskRequest{
+			ID:         created.ID,
+			Name:       "Edited Task",
+			Duration:   4,
+			Difficulty: 5,
+			Skills:     payload.Skills{" Backend "},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Edited Task", updated.Name)
+		require.Equal(t, payload.Skills{"backend"}, updated.Skills)
+
+		priority := 3
+		patched, err := svc.PatchTask(context.Background(), payload.PatchTaskRequest{ID: created.ID, Priority: &priority})
+		require.NoError(t, err)
+		require.Equal(t, 3, patched.Priority)
+		require.Equal(t, "Edited Task", patched.Name)
+
+		// A due date given as null is cleared, a missing one is kept
+		dueDate := time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)
+		patched, err = svc.PatchTask(context.Background(), payload.PatchTaskRequest{ID: created.ID, DueDate: payload.NewOptionalTime(&dueDate)})
+		require.NoError(t, err)
+		require.True(t, dueDate.Equal(*patched.DueDate))
+		var req payload.PatchTaskRequest
+		require.NoError(t, json.Unmarshal([]byte(`{"priority": 4}`), &req))
+		req.ID = created.ID
+		patched, err = svc.PatchTask(context.Background(), req)
+		require.NoError(t, err)
+		require.NotNil(t, patched.DueDate)
+		req = payload.PatchTaskRequest{}
+		require.NoError(t, json.Unmarshal([]byte(`{"dueDate": null}`), &req))
+		req.ID = created.ID
+		patched, err = svc.PatchTask(context.Background(), req)
+		require.NoError(t, err)
+		require.Nil(t, patched.DueDate)
+		require.Equal(t, 4, patched.Priority)
+
+		require.NoError(t, svc.DeleteTask(context.Background(), payload.DeleteTaskRequest{ID: created.ID}))
+		_, err = svc.GetTask(context.Background(), payload.GetTaskRequest{ID: created.ID})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+		require.ErrorIs(t, svc.DeleteTask(context.Background(), payload.DeleteTaskRequest{ID: created.ID}), repository.ErrNotFound)
+	})
 }
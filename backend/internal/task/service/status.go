@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+)
+
+// ErrInvalidTransition is returned when a task cannot move to the requested status.
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// UpdateTaskStatus implements Service. A task that is done is complete, a task
+// reopened from done starts over unless a percentage is given. The update only
+// applies while the task is still in the status the transition was checked
+// against.
+func (s *service) UpdateTaskStatus(ctx context.Context, req payload.UpdateTaskStatusRequest) (payload.Task, error) {
+	s.logger.Trace("Updating task status id=%v, status=%v", req.ID, req.Status)
+
+	task, err := s.repository.GetTask(ctx, payload.GetTaskRequest{ID: req.ID})
+	if err != nil {
+		s.logger.Error("Failed to get task id=%v: error=%v", req.ID, err)
+		return payload.Task{}, err
+	}
+	if !task.Status.CanTransitionTo(req.Status) {
+		s.logger.Warn("Invalid status transition id=%v, from=%v, to=%v", req.ID, task.Status, req.Status)
+		return payload.Task{}, fmt.Errorf("%w: task %v cannot move from %q to %q", ErrInvalidTransition, req.ID, task.Status, req.Status)
+	}
+	if req.Status == payload.StatusDone {
+		complete := 100
+		req.PercentComplete = &complete
+	} else if task.Status == payload.StatusDone && req.PercentComplete == nil {
+		reset := 0
+		req.PercentComplete = &reset
+	}
+	req.FromStatus = task.Status
+
+	resp, err := s.repository.UpdateTaskStatus(ctx, req)
+	if errors.Is(err, repository.ErrChanged) {
+		s.logger.Warn("Task status changed concurrently id=%v, from=%v, to=%v", req.ID, task.Status, req.Status)
+		return payload.Task{}, fmt.Errorf("%w: task %v is no longer %q", ErrInvalidTransition, req.ID, task.Status)
+	}
+	if err != nil {
+		s.logger.Error("Failed to update task status id=%v: error=%v", req.ID, err)
+		return resp, err
+	}
+	s.logger.Trace("Task status updated successfully id=%v, from=%v, to=%v", req.ID, task.Status, resp.Status)
+	return resp, nil
+}
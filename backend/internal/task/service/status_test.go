@@ -0,0 +1,155 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+	"github.com/mehmetali10/task-planner/internal/pkg/repository"
+	"github.com/mehmetali10/task-planner/internal/task/service"
+	"github.com/stretchr/testify/require"
+)
+
+func (f *fakeRepo) GetTask(ctx context.Context, req payload.GetTaskRequest) (payload.Task, error) {
+	for _, task := range f.tasks {
+		if task.ID == req.ID {
+			return task, nil
+		}
+	}
+	return payload.Task{}, fmt.Errorf("task with id=%v: %w", req.ID, repository.ErrNotFound)
+}
+
+func (f *fakeRepo) UpdateTaskStatus(ctx context.Context, req payload.UpdateTaskStatusRequest) (payload.Task, error) {
+	for i, task := range f.tasks {
+		if task.ID != req.ID {
+			continue
+		}
+		if req.FromStatus != "" && task.Status != req.FromStatus {
+			return payload.Task{}, fmt.Errorf("task with id=%v: %w", req.ID, repository.ErrChanged)
+		}
+		task.Status = req.Status
+		if req.PercentComplete != nil {
+			task.PercentComplete = *req.PercentComplete
+		}
+		if req.AssigneeID != nil {
+			task.AssigneeID = req.AssigneeID
+		}
+		f.tasks[i] = task
+		return task, nil
+	}
+	return payload.Task{}, fmt.Errorf("task with id=%v: %w", req.ID, repository.ErrNotFound)
+}
+
+// staleRepo reads tasks in an old status, as if they changed between the read
+// and the update.
+type staleRepo struct {
+	fakeRepo
+	status payload.TaskStatus
+}
+
+func (s *staleRepo) GetTask(ctx context.Context, req payload.GetTaskRequest) (payload.Task, error) {
+	task, err := s.fakeRepo.GetTask(ctx, req)
+	task.Status = s.status
+	return task, err
+}
+
+func TestUpdateTaskStatus(t *testing.T) {
+	t.Run("Transitions", func(t *testing.T) {
+		repo := &fakeRepo{tasks: seedTasks(1), developers: []payload.Developer{{ID: 1, FirstName: "DEV1", Capacity: 1}}}
+		svc := newScheduleService(t, repo)
+
+		half := 50
+		task, err := svc.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: 1, Status: payload.StatusInProgress, PercentComplete: &half})
+		require.NoError(t, err)
+		require.Equal(t, payload.StatusInProgress, task.Status)
+		require.Equal(t, 50, task.PercentComplete)
+
+		task, err = svc.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: 1, Status: payload.StatusDone})
+		require.NoError(t, err)
+		require.Equal(t, 100, task.PercentComplete)
+
+		// A finished task can only be reopened
+		_, err = svc.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: 1, Status: payload.StatusBacklog})
+		require.ErrorIs(t, err, service.ErrInvalidTransition)
+		task, err = svc.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: 1, Status: payload.StatusInProgress})
+		require.NoError(t, err)
+
+		// A reopened task starts over and is scheduled again
+		require.Equal(t, 0, task.PercentComplete)
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{})
+		require.NoError(t, err)
+		require.NotZero(t, resp.TotalElapsedWorkHour)
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		repo := &staleRepo{fakeRepo: fakeRepo{tasks: seedTasks(1)}, status: payload.StatusInProgress}
+		repo.tasks[0].Status = payload.StatusDone
+		svc := newScheduleService(t, repo)
+
+		// The task was finished after it was read as in progress
+		_, err := svc.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: 1, Status: payload.StatusDone})
+		require.ErrorIs(t, err, service.ErrInvalidTransition)
+		require.Equal(t, payload.StatusDone, repo.tasks[0].Status)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		svc := newScheduleService(t, &fakeRepo{})
+
+		_, err := svc.UpdateTaskStatus(context.Background(), payload.UpdateTaskStatusRequest{ID: 1, Status: payload.StatusDone})
+		require.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
+
+func TestScheduleTaskStatus(t *testing.T) {
+	assignee := uint(2)
+	tasks := []payload.Task{
+		{ID: 1, Name: "Done", Difficulty: 10, Duration: 1, Status: payload.StatusDone},
+		{ID: 2, Name: "Cancelled", Difficulty: 10, Duration: 1, Status: payload.StatusCancelled},
+		{ID: 3, Name: "Half done", Difficulty: 10, Duration: 1, Status: payload.StatusInProgress, PercentComplete: 50},
+		{ID: 4, Name: "Assigned", Difficulty: 10, Duration: 1, AssigneeID: &assignee},
+	}
+	schedule := func(t *testing.T, strategy string, tasks []payload.Task, developers []payload.Developer) (payload.ScheduleAssignmentResponse, map[uint]uint) {
+		repo := &fakeRepo{tasks: append([]payload.Task(nil), tasks...), developers: developers}
+		svc := newScheduleService(t, repo)
+
+		resp, err := svc.ScheduleAssignments(context.Background(), payload.ScheduleAssignmentRequest{Strategy: strategy})
+		require.NoError(t, err)
+		require.Empty(t, resp.Unassignable)
+
+		scheduled := map[uint]uint{}
+		for _, assignment := range resp.Assignments {
+			for _, devTasks := range assignment.DeveloperTasks {
+				for _, task := range devTasks.Tasks {
+					scheduled[task.ID] = devTasks.Developer.ID
+				}
+			}
+		}
+		return resp, scheduled
+	}
+
+	// Every strategy keeps assigned tasks with their assignee
+	for _, strategy := range service.SchedulerNames() {
+		t.Run(strategy, func(t *testing.T) {
+			developers := []payload.Developer{
+				{ID: 1, FirstName: "DEV1", Capacity: 1},
+				{ID: 2, FirstName: "DEV2", Capacity: 1},
+			}
+			resp, scheduled := schedule(t, strategy, tasks, developers)
+			require.Equal(t, map[uint]uint{3: 1, 4: 2}, scheduled)
+
+			// Only the remaining half of task 3 is planned
+			require.Equal(t, uint(15), resp.TotalElapsedWorkHour)
+
+			// Also when another developer is free or would finish the task sooner
+			_, scheduled = schedule(t, strategy, tasks[3:], developers)
+			require.Equal(t, map[uint]uint{4: 2}, scheduled)
+			_, scheduled = schedule(t, strategy, tasks, []payload.Developer{
+				{ID: 1, FirstName: "DEV1", Capacity: 3},
+				{ID: 2, FirstName: "DEV2", Capacity: 1},
+			})
+			require.Len(t, scheduled, 2)
+			require.Equal(t, uint(2), scheduled[4])
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+func init() {
+	RegisterScheduler(greedyScheduler{})
+	RegisterScheduler(lptScheduler{})
+	RegisterScheduler(bestFitScheduler{})
+	RegisterScheduler(branchAndBoundScheduler{maxTasks: 10, maxNodes: 2_000_000})
+}
+
+// greedyScheduler fills the weeks one after another. Tasks are taken longest
+// duration first, after their prerequisites, and given to the first
+// developer, in ID order, with room left. Developers left without tasks in a
+// week then take over tasks of the busiest developers, see rebalanceWorkload.
+type greedyScheduler struct{}
+
+func (greedyScheduler) Name() string { return "greedy" }
+
+func (greedyScheduler) Schedule(tt *Timetable, tasks []payload.Task) {
+	// Sort tasks by duration in descending order
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Duration > tasks[j].Duration
+	})
+
+	// Tasks no developer can take would be carried over forever
+	remainingTasks := tt.Order(tt.Placeable(tasks))
+	for week := 0; len(remainingTasks) > 0 && week < tt.Horizon(); week++ {
+		var newRemainingTasks []payload.Task
+		for _, task := range remainingTasks {
+			assigned := false
+			for dev := range tt.Developers() {
+				// Check if the developer can handle the task within weekly limits
+				if tt.CanTake(task, dev) && tt.Fits(task, dev, week) {
+					tt.Assign(task, dev, week)
+					assigned = true
+					break
+				}
+			}
+
+			// If the task could not be assigned, keep it for the next week
+			if !assigned {
+				newRemainingTasks = append(newRemainingTasks, task)
+			}
+		}
+
+		// Rebalance tasks between developers to ensure fair workload distribution
+		rebalanceWorkload(tt, week)
+		remainingTasks = newRemainingTasks
+	}
+}
+
+// rebalanceWorkload ensures the tasks of a week are fairly distributed among
+// developers. Every developer without a task in the week takes over the last
+// task of the developer with the most tasks, as long as that developer keeps
+// at least one and the task fits.
+func rebalanceWorkload(tt *Timetable, week int) {
+	for idle := range tt.Developers() {
+		if tt.Booked(idle, week) > 0 {
+			continue
+		}
+		busiest := -1
+		for dev := range tt.Developers() {
+			if tt.Booked(dev, week) > 1 && (busiest < 0 || tt.Booked(dev, week) > tt.Booked(busiest, week)) {
+				busiest = dev
+			}
+		}
+		if busiest < 0 {
+			return
+		}
+
+		task, ok := tt.Unassign(busiest, week)
+		if !ok {
+			continue
+		}
+		// A task that does not fit is put back where it was
+		if tt.CanTake(task, idle) && tt.Fits(task, idle, week) {
+			tt.Assign(task, idle, week)
+		} else {
+			tt.Assign(task, busiest, week)
+		}
+	}
+}
+
+// lptScheduler implements the longest-processing-time rule: the hardest task
+// goes to the developer who would finish it the earliest.
+type lptScheduler struct{}
+
+func (lptScheduler) Name() string { return "lpt" }
+
+func (lptScheduler) Schedule(tt *Timetable, tasks []payload.Task) {
+	sortByDifficulty(tasks)
+
+	for _, task := range tt.Order(tasks) {
+		bestDev, bestWeek := -1, 0
+		bestFinish := 0.0
+		for dev := range tt.Developers() {
+			week, ok := tt.NextFit(task, dev)
+			if !ok {
+				continue
+			}
+			if finish := tt.FinishWith(task, dev, week); bestDev < 0 || finish < bestFinish {
+				bestDev, bestWeek, bestFinish = dev, week, finish
+			}
+		}
+		if bestDev >= 0 {
+			tt.Assign(task, bestDev, bestWeek)
+		}
+	}
+}
+
+// bestFitScheduler treats every developer week as a bin and puts each task,
+// hardest first, into the earliest bin that leaves the least free time behind.
+type bestFitScheduler struct{}
+
+func (bestFitScheduler) Name() string { return "best-fit" }
+
+func (bestFitScheduler) Schedule(tt *Timetable, tasks []payload.Task) {
+	sortByDifficulty(tasks)
+
+	for _, task := range tt.Order(tasks) {
+		bestDev, bestWeek := -1, 0
+		bestLeft := 0.0
+		for dev := range tt.Developers() {
+			week, ok := tt.FirstFit(task, dev)
+			if !ok {
+				continue
+			}
+			left := tt.Free(dev, week) - tt.Effort(task, dev)
+			if bestDev < 0 || week < bestWeek || (week == bestWeek && left < bestLeft) {
+				bestDev, bestWeek, bestLeft = dev, week, left
+			}
+		}
+		if bestDev >= 0 {
+			tt.Assign(task, bestDev, bestWeek)
+		}
+	}
+}
+
+// sortByDifficulty orders tasks by difficulty, and therefore by effort, in descending order.
+func sortByDifficulty(tasks []payload.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Difficulty != tasks[j].Difficulty {
+			return tasks[i].Difficulty > tasks[j].Difficulty
+		}
+		return tasks[i].Duration > tasks[j].Duration
+	})
+}
@@ -0,0 +1,1001 @@
+package service
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/mehmetali10/task-planner/internal/pkg/payload"
+)
+
+// epsilon absorbs rounding errors when comparing hours.
+const epsilon = 1e-9
+
+// DefaultHorizon is the number of weeks a timetable may use unless configured otherwise.
+const DefaultHorizon = 520
+
+// SplitMode selects whether tasks that do not fit into a developer's week may be split.
+type SplitMode int
+
+const (
+	// SplitNone leaves tasks larger than a week unassigned.
+	SplitNone SplitMode = iota
+	// SplitWeeks lets a developer work on a task over consecutive weeks.
+	SplitWeeks
+	// SplitDevelopers also lets other developers work on the task in parallel.
+	SplitDevelopers
+)
+
+// Timetable records which tasks each developer works on week by week.
+// Developers are addressed by their index in the slice given to NewTimetable.
+type Timetable struct {
+	developers []payload.Developer
+	// devIndex maps developer IDs to their index
+	devIndex map[uint]int
+	calendar *Calendar
+	absences map[uint][]payload.Absence
+	weeks    []timetableWeek
+
+	// prerequisites lists, for every task, the tasks that have to be finished first
+	prerequisites map[uint][]uint
+	// finish records the team hour at which every booked task is finished
+	finish map[uint]float64
+
+	// split selects whether tasks larger than a week may be split
+	split SplitMode
+	// horizon is the number of weeks the timetable may use
+	horizon int
+	// firstWeek is the first week new tasks may be booked into
+	firstWeek int
+
+	// softSkills lets developers take tasks they lack skills for, at skillPenalty times the effort per missing skill
+	softSkills   bool
+	skillPenalty float64
+
+	// days caches the available days of every developer per week
+	days [][][]workDay
+}
+
+type timetableWeek struct {
+	// load is the hour of the developer's week at which the last booking ends
+	load     []float64
+	bookings [][]booking
+}
+
+// booking is a task, or a chunk of a split task, placed into a developer's week.
+type booking struct {
+	task   payload.Task
+	start  float64
+	effort float64
+	// part numbers the chunks of a split task, starting at 1
+	part  int
+	parts int
+	// pinned is set for work kept where an earlier plan put it
+	pinned bool
+}
+
+// workDay is a day on which a developer is available.
+type workDay struct {
+	date  time.Time
+	hours float64
+}
+
+// NewTimetable creates an empty timetable for the given developers. The
+// availability of a developer follows the calendar, scaled by the developer's
+// weekly hours and part-time percentage, minus the days of their absences.
+func NewTimetable(developers []payload.Developer, calendar *Calendar, absences []payload.Absence) *Timetable {
+	tt := &Timetable{
+		developers:    developers,
+		calendar:      calendar,
+		devIndex:      make(map[uint]int, len(developers)),
+		absences:      map[uint][]payload.Absence{},
+		prerequisites: map[uint][]uint{},
+		finish:        map[uint]float64{},
+		horizon:       DefaultHorizon,
+		days:          make([][][]workDay, len(developers)),
+	}
+	for i, developer := range developers {
+		tt.devIndex[developer.ID] = i
+	}
+	for _, absence := range absences {
+		tt.absences[absence.DeveloperID] = append(tt.absences[absence.DeveloperID], absence)
+	}
+	return tt
+}
+
+// Developers returns the developers of the timetable.
+func (tt *Timetable) Developers() []payload.Developer {
+	return tt.developers
+}
+
+// Weeks returns the number of weeks up to and including the last booked one.
+func (tt *Timetable) Weeks() int {
+	return len(tt.weeks)
+}
+
+// Calendar returns the working calendar of the timetable.
+func (tt *Timetable) Calendar() *Calendar {
+	return tt.calendar
+}
+
+// SetDependencies makes tasks wait for the tasks they depend on. Dependencies
+// on tasks outside the given set are ignored, as those are not scheduled.
+// It returns ErrDependencyCycle when the dependencies contain a cycle.
+func (tt *Timetable) SetDependencies(tasks []payload.Task, dependencies []payload.TaskDependency) error {
+	scheduled := make(map[uint]bool, len(tasks))
+	for _, task := range tasks {
+		scheduled[task.ID] = true
+	}
+	tt.prerequisites = map[uint][]uint{}
+	for _, d := range dependencies {
+		if scheduled[d.TaskID] && scheduled[d.DependsOnID] {
+			tt.prerequisites[d.TaskID] = append(tt.prerequisites[d.TaskID], d.DependsOnID)
+		}
+	}
+	if len(tt.Order(tasks)) < len(tasks) {
+		return fmt.Errorf("%w between the scheduled tasks", ErrDependencyCycle)
+	}
+	return nil
+}
+
+// SetSplitting selects whether tasks larger than a week may be split.
+func (tt *Timetable) SetSplitting(mode SplitMode) {
+	tt.split = mode
+}
+
+// SetHorizon limits the number of weeks the timetable may use. Tasks that
+// cannot be finished within the horizon are not booked.
+func (tt *Timetable) SetHorizon(weeks int) {
+	tt.horizon = weeks
+}
+
+// Horizon returns the number of weeks the timetable may use.
+func (tt *Timetable) Horizon() int {
+	return tt.horizon
+}
+
+// SetFirstWeek closes the weeks before the given one, for example because
+// they have already elapsed. Only Pin books work into closed weeks.
+func (tt *Timetable) SetFirstWeek(week int) {
+	tt.firstWeek = week
+}
+
+// SetSkillMatching selects how the skills required by tasks are matched. With
+// hard matching a task is only given to developers holding all its skills.
+// With soft matching anyone can take it, but the effort is multiplied by the
+// penalty for every missing skill.
+func (tt *Timetable) SetSkillMatching(soft bool, penalty float64) {
+	tt.softSkills = soft
+	tt.skillPenalty = penalty
+}
+
+// Placeable returns the tasks, in their original order, that at least one
+// developer can take and whose prerequisites are placeable or booked already.
+func (tt *Timetable) Placeable(tasks []payload.Task) []payload.Task {
+	kept := make(map[uint]bool, len(tasks))
+	for _, task := range tt.Order(tasks) {
+		if !tt.prerequisitesIn(task, kept) {
+			continue
+		}
+		for dev := range tt.developers {
+			if tt.CanTake(task, dev) {
+				kept[task.ID] = true
+				break
+			}
+		}
+	}
+
+	placeable := make([]payload.Task, 0, len(kept))
+	for _, task := range tasks {
+		if kept[task.ID] {
+			placeable = append(placeable, task)
+		}
+	}
+	return placeable
+}
+
+// prerequisitesIn reports whether all prerequisites of a task are in the set
+// or booked already.
+func (tt *Timetable) prerequisitesIn(task payload.Task, set map[uint]bool) bool {
+	for _, id := range tt.prerequisites[task.ID] {
+		if _, booked := tt.finish[id]; !set[id] && !booked {
+			return false
+		}
+	}
+	return true
+}
+
+// Reasons why a task could not be assigned.
+const (
+	ReasonMissingSkills   = "missing_skills"
+	ReasonExceedsCapacity = "exceeds_capacity"
+	ReasonBlocked         = "blocked_by_dependency"
+	ReasonBeyondHorizon   = "beyond_horizon"
+	ReasonAssignee        = "assignee_unavailable"
+)
+
+// Unassigned returns the tasks that have not been booked, with the reason why.
+func (tt *Timetable) Unassigned(tasks []payload.Task) []payload.UnassignableTask {
+	unassigned := []payload.UnassignableTask{}
+	for _, task := range tasks {
+		if _, ok := tt.finish[task.ID]; !ok {
+			reason, message := tt.unassignedReason(task)
+			unassigned = append(unassigned, payload.UnassignableTask{
+				Task:    task,
+				Reason:  reason,
+				Message: message,
+			})
+		}
+	}
+	return unassigned
+}
+
+func (tt *Timetable) unassignedReason(task payload.Task) (string, string) {
+	var blockedBy []uint
+	for _, id := range tt.prerequisites[task.ID] {
+		if _, ok := tt.finish[id]; !ok {
+			blockedBy = append(blockedBy, id)
+		}
+	}
+	if len(blockedBy) > 0 {
+		return ReasonBlocked, fmt.Sprintf("depends on tasks %v, which could not be assigned", blockedBy)
+	}
+
+	if dev, ok := tt.assignee(task); ok && !tt.CanTake(task, dev) {
+		return ReasonAssignee, fmt.Sprintf("is assigned to developer %d, who cannot take it", tt.developers[dev].ID)
+	}
+
+	qualified := false
+	for dev := range tt.developers {
+		if tt.CanTake(task, dev) {
+			return ReasonBeyondHorizon, fmt.Sprintf("cannot be finished within the planning horizon of %d weeks", tt.horizon)
+		}
+		if tt.softSkills || tt.developers[dev].Skills.Missing(task.Skills) == 0 {
+			qualified = true
+		}
+	}
+	if !qualified {
+		return ReasonMissingSkills, fmt.Sprintf("no developer has all of the skills %v", task.Skills)
+	}
+	if tt.split == SplitNone {
+		return ReasonExceedsCapacity, "takes longer than any qualified developer works in a week and splitting is disabled"
+	}
+	return ReasonExceedsCapacity, "no qualified developer has working hours to spend on it"
+}
+
+// Order returns the tasks so that every task comes after its prerequisites.
+// Among the tasks that are ready, the most urgent one comes first: the one
+// with the highest priority, then the earliest due date, then the longest
+// chain of dependent work behind it. A task is as urgent as the most urgent
+// task waiting for it. Ties keep the order of the input, so tasks without
+// priorities, due dates or dependencies stay in the order the strategy sorted
+// them in. Tasks that are part of a cycle are left out.
+func (tt *Timetable) Order(tasks []payload.Task) []payload.Task {
+	index := make(map[uint]int, len(tasks))
+	for i, task := range tasks {
+		index[task.ID] = i
+	}
+	waiting := make([]int, len(tasks))
+	dependents := make([][]int, len(tasks))
+	for i, task := range tasks {
+		for _, id := range tt.prerequisites[task.ID] {
+			if j, ok := index[id]; ok {
+				waiting[i]++
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+	}
+
+	// Urgencies are computed once per task, dependents before the tasks they
+	// wait for. Tasks of a cycle and the tasks after them keep their own.
+	var topo []int
+	remaining := append([]int(nil), waiting...)
+	for i := range tasks {
+		if remaining[i] == 0 {
+			topo = append(topo, i)
+		}
+	}
+	for k := 0; k < len(topo); k++ {
+		for _, j := range dependents[topo[k]] {
+			if remaining[j]--; remaining[j] == 0 {
+				topo = append(topo, j)
+			}
+		}
+	}
+	urgencies := make([]urgency, len(tasks))
+	for i, task := range tasks {
+		urgencies[i] = urgency{priority: task.Priority, due: task.DueDate}
+	}
+	for k := len(topo) - 1; k >= 0; k-- {
+		i := topo[k]
+		for _, j := range dependents[i] {
+			urgencies[i] = urgencies[i].inherit(urgencies[j], tasks[j].Difficulty)
+		}
+	}
+
+	ready := &readyTasks{urgencies: urgencies}
+	for i := range tasks {
+		if waiting[i] == 0 {
+			ready.indexes = append(ready.indexes, i)
+		}
+	}
+	heap.Init(ready)
+	ordered := make([]payload.Task, 0, len(topo))
+	for ready.Len() > 0 {
+		i := heap.Pop(ready).(int)
+		ordered = append(ordered, tasks[i])
+		for _, j := range dependents[i] {
+			if waiting[j]--; waiting[j] == 0 {
+				heap.Push(ready, j)
+			}
+		}
+	}
+	return ordered
+}
+
+// readyTasks is a heap of the indexes of the tasks that are ready, the most
+// urgent first and ties in the order of the input.
+type readyTasks struct {
+	indexes   []int
+	urgencies []urgency
+}
+
+func (r *readyTasks) Len() int { return len(r.indexes) }
+
+func (r *readyTasks) Less(a, b int) bool {
+	i, j := r.indexes[a], r.indexes[b]
+	if r.urgencies[i].before(r.urgencies[j]) {
+		return true
+	}
+	return !r.urgencies[j].before(r.urgencies[i]) && i < j
+}
+
+func (r *readyTasks) Swap(a, b int) { r.indexes[a], r.indexes[b] = r.indexes[b], r.indexes[a] }
+
+func (r *readyTasks) Push(x interface{}) { r.indexes = append(r.indexes, x.(int)) }
+
+func (r *readyTasks) Pop() interface{} {
+	last := r.indexes[len(r.indexes)-1]
+	r.indexes = r.indexes[:len(r.indexes)-1]
+	return last
+}
+
+// urgency ranks the tasks that are ready to be scheduled.
+type urgency struct {
+	priority int
+	due      *time.Time
+	// tail is the difficulty of the longest chain of tasks waiting for the task
+	tail int
+}
+
+// inherit combines the urgency of a task with that of a dependent task of the given difficulty.
+func (u urgency) inherit(dependent urgency, difficulty int) urgency {
+	if dependent.priority > u.priority {
+		u.priority = dependent.priority
+	}
+	if dependent.due != nil && (u.due == nil || dependent.due.Before(*u.due)) {
+		u.due = dependent.due
+	}
+	if chain := dependent.tail + difficulty; chain > u.tail {
+		u.tail = chain
+	}
+	return u
+}
+
+// before reports whether u is more urgent than other.
+func (u urgency) before(other urgency) bool {
+	if u.priority != other.priority {
+		return u.priority > other.priority
+	}
+	if (u.due == nil) != (other.due == nil) {
+		return u.due != nil
+	}
+	if u.due != nil && !u.due.Equal(*other.due) {
+		return u.due.Before(*other.due)
+	}
+	return u.tail > other.tail
+}
+
+// Capacity returns the hours a developer can work in a week.
+func (tt *Timetable) Capacity(dev, week int) float64 {
+	capacity := 0.0
+	for _, day := range tt.Days(dev, week) {
+		capacity += day.hours
+	}
+	return capacity
+}
+
+// Days returns the days of a week on which a developer is available.
+func (tt *Timetable) Days(dev, week int) []workDay {
+	for len(tt.days[dev]) <= week {
+		w := len(tt.days[dev])
+		var days []workDay
+		for _, date := range tt.calendar.Days(w) {
+			if !tt.isAbsent(dev, date) {
+				days = append(days, workDay{date: date, hours: tt.dayHours(dev)})
+			}
+		}
+		tt.days[dev] = append(tt.days[dev], days)
+	}
+	return tt.days[dev][week]
+}
+
+// dayHours returns the hours a developer works on a full working day.
+func (tt *Timetable) dayHours(dev int) float64 {
+	developer := tt.developers[dev]
+	hours := tt.calendar.HoursPerDay()
+	if developer.WeeklyHours > 0 {
+		hours = developer.WeeklyHours / float64(tt.calendar.WorkDaysPerWeek())
+	}
+	if developer.PartTimePercent > 0 {
+		hours *= float64(developer.PartTimePercent) / 100
+	}
+	return hours
+}
+
+// maxCapacity returns the hours a developer works in a week without holidays or absences.
+func (tt *Timetable) maxCapacity(dev int) float64 {
+	return tt.dayHours(dev) * float64(tt.calendar.WorkDaysPerWeek())
+}
+
+func (tt *Timetable) isAbsent(dev int, date time.Time) bool {
+	for _, absence := range tt.absences[tt.developers[dev].ID] {
+		if !date.Before(truncateToDay(absence.StartDate)) && !date.After(truncateToDay(absence.EndDate)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Effort returns the hours a developer needs to finish what is left of a
+// task, following its percentage complete. It is infinite when the developer lacks a required skill and matching is hard.
+func (tt *Timetable) Effort(task payload.Task, dev int) float64 {
+	effort := float64(task.Difficulty) / float64(tt.developers[dev].Capacity) * task.Remaining()
+	if missing := tt.developers[dev].Skills.Missing(task.Skills); missing > 0 {
+		if !tt.softSkills {
+			return math.Inf(1)
+		}
+		effort *= math.Pow(tt.skillPenalty, float64(missing))
+	}
+	return effort
+}
+
+// Load returns the hours of a developer's week that are already booked,
+// including idle time spent waiting for prerequisites.
+func (tt *Timetable) Load(dev, week int) float64 {
+	if week >= len(tt.weeks) {
+		return 0
+	}
+	return tt.weeks[week].load[dev]
+}
+
+// Free returns the hours a developer still has available in a week.
+func (tt *Timetable) Free(dev, week int) float64 {
+	return tt.Capacity(dev, week) - tt.Load(dev, week)
+}
+
+// Ready reports whether all prerequisites of a task are booked.
+func (tt *Timetable) Ready(task payload.Task) bool {
+	for _, id := range tt.prerequisites[task.ID] {
+		if _, ok := tt.finish[id]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// readyAt returns the team hour at which the last prerequisite of a task is finished.
+func (tt *Timetable) readyAt(task payload.Task) float64 {
+	ready := 0.0
+	for _, id := range tt.prerequisites[task.ID] {
+		ready = math.Max(ready, tt.finish[id])
+	}
+	return ready
+}
+
+// startOffset returns the hour of a developer's week at which a task would
+// start: after the bookings of the week and after its prerequisites finish.
+func (tt *Timetable) startOffset(task payload.Task, dev, week int) float64 {
+	return math.Max(tt.Load(dev, week), tt.offsetAt(dev, week, tt.readyAt(task)))
+}
+
+// Fits reports whether a task can be added to a developer's week.
+func (tt *Timetable) Fits(task payload.Task, dev, week int) bool {
+	if !tt.Ready(task) {
+		return false
+	}
+	_, ok := tt.plan(task, dev, week)
+	return ok
+}
+
+// FirstFit returns the earliest week in which a developer has room for a task.
+// It reports false when the task does not fit into any week of the horizon.
+func (tt *Timetable) FirstFit(task payload.Task, dev int) (int, bool) {
+	return tt.fitFrom(task, dev, 0)
+}
+
+// NextFit returns the earliest week, not before the developer's last booked
+// week, in which the developer has room for a task.
+func (tt *Timetable) NextFit(task payload.Task, dev int) (int, bool) {
+	return tt.fitFrom(task, dev, tt.lastWeek(dev))
+}
+
+// CanTake reports whether a developer is able to finish a task, regardless of
+// what is booked already: within a regular week, or at all if tasks may be split.
+// A task assigned to one of the developers can only be taken by them.
+func (tt *Timetable) CanTake(task payload.Task, dev int) bool {
+	if assignee, ok := tt.assignee(task); ok && assignee != dev {
+		return false
+	}
+	effort := tt.Effort(task, dev)
+	if math.IsInf(effort, 0) || math.IsNaN(effort) {
+		return false
+	}
+	return effort <= tt.maxCapacity(dev) || (tt.split != SplitNone && tt.maxCapacity(dev) > 0)
+}
+
+// assignee returns the index of the developer a task is assigned to. It
+// reports false when the task has no assignee among the developers.
+func (tt *Timetable) assignee(task payload.Task) (int, bool) {
+	if task.AssigneeID == nil {
+		return 0, false
+	}
+	dev, ok := tt.devIndex[*task.AssigneeID]
+	return dev, ok
+}
+
+// NeedsSplit reports whether a developer can only finish a task by splitting
+// it over several weeks.
+func (tt *Timetable) NeedsSplit(task payload.Task, dev int) bool {
+	return tt.split != SplitNone && tt.Effort(task, dev) > tt.maxCapacity(dev)
+}
+
+func (tt *Timetable) fitFrom(task payload.Task, dev, from int) (int, bool) {
+	if from < tt.firstWeek {
+		from = tt.firstWeek
+	}
+	if !tt.CanTake(task, dev) || !tt.Ready(task) {
+		return 0, false
+	}
+	for week := from; week < tt.horizon; week++ {
+		if tt.Fits(task, dev, week) {
+			return week, true
+		}
+	}
+	return 0, false
+}
+
+// chunk is the part of a task a developer works on in one week.
+type chunk struct {
+	dev    int
+	week   int
+	start  float64
+	effort float64
+}
+
+// plan returns the chunks a task is worked on in when a developer starts it
+// in the given week. A task that fits into the week is a single chunk. A task
+// that needs splitting continues in the following weeks and, when splitting
+// across developers, also takes the free time of the other developers in
+// those weeks. It reports false when the task cannot start in the week, is
+// assigned to another developer or cannot be finished within the horizon.
+func (tt *Timetable) plan(task payload.Task, dev, week int) ([]chunk, bool) {
+	if week < tt.firstWeek || week >= tt.horizon {
+		return nil, false
+	}
+	if assignee, ok := tt.assignee(task); ok && assignee != dev {
+		return nil, false
+	}
+	start, effort := tt.startOffset(task, dev, week), tt.Effort(task, dev)
+	if !tt.NeedsSplit(task, dev) {
+		if start+effort > tt.Capacity(dev, week) {
+			return nil, false
+		}
+		return []chunk{{dev: dev, week: week, start: start, effort: effort}}, true
+	}
+	if start >= tt.Capacity(dev, week)-epsilon {
+		return nil, false
+	}
+
+	helpers := []int{dev}
+	if tt.split == SplitDevelopers {
+		for other := range tt.developers {
+			if other != dev && tt.CanTake(task, other) {
+				helpers = append(helpers, other)
+			}
+		}
+	}
+
+	// remaining is the share of the task that is still left to do
+	var chunks []chunk
+	remaining := 1.0
+	for w := week; remaining > epsilon; w++ {
+		if w >= tt.horizon {
+			return nil, false
+		}
+		for _, helper := range helpers {
+			start := tt.startOffset(task, helper, w)
+			free := tt.Capacity(helper, w) - start
+			if free <= epsilon || remaining <= epsilon {
+				continue
+			}
+			effort := tt.Effort(task, helper)
+			hours := math.Min(free, remaining*effort)
+			chunks = append(chunks, chunk{dev: helper, week: w, start: start, effort: hours})
+			remaining -= hours / effort
+		}
+	}
+	return chunks, true
+}
+
+// Assign books a task for a developer starting in the given week. The task
+// has to fit, see Fits.
+func (tt *Timetable) Assign(task payload.Task, dev, week int) {
+	chunks, _ := tt.plan(task, dev, week)
+	for i, c := range chunks {
+		tt.grow(c.week)
+		b := booking{task: task, start: c.start, effort: c.effort}
+		if len(chunks) > 1 {
+			b.part, b.parts = i+1, len(chunks)
+		}
+		tt.weeks[c.week].load[c.dev] = c.start + c.effort
+		tt.weeks[c.week].bookings[c.dev] = append(tt.weeks[c.week].bookings[c.dev], b)
+	}
+	tt.finish[task.ID] = tt.chunksFinish(chunks)
+}
+
+// Pin books a task, or a chunk of a split task, where an earlier plan put
+// it: for a developer in the given week, after the work already booked there.
+// Pinned work is kept as it is, so closed weeks, the horizon and the
+// developer's capacity are not checked.
+func (tt *Timetable) Pin(task payload.Task, dev, week int, hours float64, part, parts int) {
+	tt.grow(week)
+	start := tt.Load(dev, week)
+	tt.weeks[week].load[dev] = start + hours
+	tt.weeks[week].bookings[dev] = append(tt.weeks[week].bookings[dev], booking{task: task, start: start, effort: hours, part: part, parts: parts, pinned: true})
+
+	_, end := tt.locate(dev, week, start+hours)
+	tt.finish[task.ID] = math.Max(tt.finish[task.ID], end)
+}
+
+// Booked returns the number of tasks, or chunks of split tasks, booked for a
+// developer in a week.
+func (tt *Timetable) Booked(dev, week int) int {
+	if week >= len(tt.weeks) {
+		return 0
+	}
+	return len(tt.weeks[week].bookings[dev])
+}
+
+// Unassign removes the task booked last for a developer in a week, so it can
+// be booked elsewhere. It reports false, keeping the booking, when the week
+// is closed, the task is pinned or split, or other booked tasks depend on it.
+func (tt *Timetable) Unassign(dev, week int) (payload.Task, bool) {
+	if week < tt.firstWeek || tt.Booked(dev, week) == 0 {
+		return payload.Task{}, false
+	}
+	bookings := tt.weeks[week].bookings[dev]
+	last := bookings[len(bookings)-1]
+	if last.pinned || last.parts > 0 {
+		return payload.Task{}, false
+	}
+	for _, w := range tt.weeks {
+		for _, devBookings := range w.bookings {
+			for _, b := range devBookings {
+				for _, id := range tt.prerequisites[b.task.ID] {
+					if id == last.task.ID {
+						return payload.Task{}, false
+					}
+				}
+			}
+		}
+	}
+
+	bookings = bookings[:len(bookings)-1]
+	tt.weeks[week].bookings[dev] = bookings
+	tt.weeks[week].load[dev] = 0
+	if len(bookings) > 0 {
+		previous := bookings[len(bookings)-1]
+		tt.weeks[week].load[dev] = previous.start + previous.effort
+	}
+	delete(tt.finish, last.task.ID)
+	return last.task, true
+}
+
+// grow adds empty weeks up to and including the given one.
+func (tt *Timetable) grow(week int) {
+	for len(tt.weeks) <= week {
+		tt.weeks = append(tt.weeks, timetableWeek{
+			load:     make([]float64, len(tt.developers)),
+			bookings: make([][]booking, len(tt.developers)),
+		})
+	}
+}
+
+// chunksFinish returns the team hour at which the last chunk ends.
+func (tt *Timetable) chunksFinish(chunks []chunk) float64 {
+	finish := 0.0
+	for _, c := range chunks {
+		_, end := tt.locate(c.dev, c.week, c.start+c.effort)
+		finish = math.Max(finish, end)
+	}
+	return finish
+}
+
+// Finish returns the working hour, counted from the start of the plan, at
+// which a developer completes the last booked task.
+func (tt *Timetable) Finish(dev int) float64 {
+	week := tt.lastWeek(dev)
+	if week < 0 {
+		return 0
+	}
+	_, hour := tt.locate(dev, week, tt.Load(dev, week))
+	return hour
+}
+
+// FinishWith returns the finish hour of a developer after adding a task to one of their weeks.
+func (tt *Timetable) FinishWith(task payload.Task, dev, week int) float64 {
+	chunks, _ := tt.plan(task, dev, week)
+	return math.Max(tt.chunksFinish(chunks), tt.Finish(dev))
+}
+
+// Makespan returns the working hour at which the last developer finishes.
+func (tt *Timetable) Makespan() float64 {
+	makespan := 0.0
+	for dev := range tt.developers {
+		makespan = math.Max(makespan, tt.Finish(dev))
+	}
+	return makespan
+}
+
+// TotalHours returns the sum of all booked task hours.
+func (tt *Timetable) TotalHours() float64 {
+	total := 0.0
+	for _, week := range tt.weeks {
+		for _, bookings := range week.bookings {
+			for _, b := range bookings {
+				total += b.effort
+			}
+		}
+	}
+	return total
+}
+
+// CriticalPath returns the chain of dependent booked tasks with the most
+// working hours, which no assignment of developers can make shorter, and its hours.
+func (tt *Timetable) CriticalPath() ([]payload.Task, float64) {
+	var tasks []payload.Task
+	effort := map[uint]float64{}
+	for _, week := range tt.weeks {
+		for _, bookings := range week.bookings {
+			for _, b := range bookings {
+				if _, ok := effort[b.task.ID]; !ok {
+					tasks = append(tasks, b.task)
+				}
+				effort[b.task.ID] += b.effort
+			}
+		}
+	}
+
+	// Tasks come after their prerequisites, so every chain is complete when
+	// its last task is reached
+	hours := map[uint]float64{}
+	previous := map[uint]uint{}
+	if len(tasks) == 0 {
+		return []payload.Task{}, 0
+	}
+	ordered := tt.Order(tasks)
+	last := ordered[0].ID
+	for _, task := range ordered {
+		for _, id := range tt.prerequisites[task.ID] {
+			if _, ok := effort[id]; ok && hours[id] > hours[task.ID] {
+				hours[task.ID], previous[task.ID] = hours[id], id
+			}
+		}
+		hours[task.ID] += effort[task.ID]
+		if hours[task.ID] > hours[last] {
+			last = task.ID
+		}
+	}
+
+	byID := make(map[uint]payload.Task, len(tasks))
+	for _, task := range tasks {
+		byID[task.ID] = task
+	}
+	path := []payload.Task{}
+	for id, ok := last, true; ok; id, ok = previous[id] {
+		path = append([]payload.Task{byID[id]}, path...)
+	}
+	return path, hours[last]
+}
+
+// LateTasks returns the booked tasks that are projected to end after their
+// due date, ordered by due date.
+func (tt *Timetable) LateTasks() []payload.LateTask {
+	ends := map[uint]payload.LateTask{}
+	var ids []uint
+	for week := range tt.weeks {
+		for dev, bookings := range tt.weeks[week].bookings {
+			for _, b := range bookings {
+				if b.task.DueDate == nil {
+					continue
+				}
+				// A split task ends with its last chunk
+				end := tt.moment(dev, week, b.start+b.effort, false)
+				if last, ok := ends[b.task.ID]; !ok || end.After(last.End) {
+					if !ok {
+						ids = append(ids, b.task.ID)
+					}
+					ends[b.task.ID] = payload.LateTask{Task: b.task, End: end}
+				}
+			}
+		}
+	}
+
+	late := []payload.LateTask{}
+	for _, id := range ids {
+		task := ends[id]
+		due := truncateToDay(*task.Task.DueDate)
+		if end := truncateToDay(task.End); end.After(due) {
+			task.DaysLate = int(end.Sub(due).Hours() / 24)
+			late = append(late, task)
+		}
+	}
+	sort.SliceStable(late, func(i, j int) bool {
+		return late[i].Task.DueDate.Before(*late[j].Task.DueDate)
+	})
+	return late
+}
+
+// Assignments converts the timetable into the weekly assignments of the response.
+func (tt *Timetable) Assignments() []payload.Assignment {
+	assignments := make([]payload.Assignment, 0, len(tt.weeks))
+	for i, week := range tt.weeks {
+		startDate, endDate := tt.calendar.WeekStart(i), tt.calendar.WeekEnd(i)
+		assignment := payload.Assignment{
+			Week:           uint(i + 1),
+			StartDate:      &startDate,
+			EndDate:        &endDate,
+			DeveloperTasks: []payload.DeveloperTaskAssignment{},
+		}
+		for dev, bookings := range week.bookings {
+			if len(bookings) == 0 {
+				continue
+			}
+			devTasks := payload.DeveloperTaskAssignment{
+				Developer: tt.developers[dev],
+				Tasks:     make([]payload.Task, 0, len(bookings)),
+			}
+			for _, b := range bookings {
+				devTasks.Tasks = append(devTasks.Tasks, b.task)
+				if b.parts > 0 {
+					devTasks.Chunks = append(devTasks.Chunks, payload.TaskChunk{
+						TaskID: b.task.ID,
+						Part:   b.part,
+						Parts:  b.parts,
+						Hours:  b.effort,
+					})
+				}
+			}
+			assignment.DeveloperTasks = append(assignment.DeveloperTasks, devTasks)
+		}
+		assignments = append(assignments, assignment)
+	}
+	return assignments
+}
+
+// Timeline lists the tasks of every developer in the order they are worked
+// on, with the moments each task, or chunk of a split task, starts and ends.
+func (tt *Timetable) Timeline() []payload.DeveloperTimeline {
+	timeline := []payload.DeveloperTimeline{}
+	for dev, developer := range tt.developers {
+		slots := []payload.TimelineSlot{}
+		for week := range tt.weeks {
+			for _, b := range tt.weeks[week].bookings[dev] {
+				slots = append(slots, payload.TimelineSlot{
+					Task:  b.task,
+					Week:  uint(week + 1),
+					Start: tt.moment(dev, week, b.start, true),
+					End:   tt.moment(dev, week, b.start+b.effort, false),
+					Hours: b.effort,
+					Part:  b.part,
+					Parts: b.parts,
+				})
+			}
+		}
+		if len(slots) > 0 {
+			timeline = append(timeline, payload.DeveloperTimeline{
+				Developer: developer,
+				Slots:     slots,
+			})
+		}
+	}
+	return timeline
+}
+
+// FinishDate returns the working day on which the last task of the plan is completed.
+func (tt *Timetable) FinishDate() time.Time {
+	finish := tt.calendar.Start()
+	for dev := range tt.developers {
+		week := tt.lastWeek(dev)
+		if week < 0 {
+			continue
+		}
+		if day, _ := tt.locate(dev, week, tt.Load(dev, week)); day.After(finish) {
+			finish = day
+		}
+	}
+	return finish
+}
+
+// locate finds the moment a developer has worked the given hours of a week.
+// It returns the day it happens on and the working hour of the team calendar,
+// counted from the start of the plan, so moments of developers with different
+// availability can be compared.
+func (tt *Timetable) locate(dev, week int, hours float64) (time.Time, float64) {
+	start := tt.calendar.HoursBefore(week)
+	days := tt.Days(dev, week)
+	if len(days) == 0 {
+		return tt.calendar.WeekStart(week), start
+	}
+	for _, day := range days {
+		if hours <= day.hours+epsilon {
+			return day.date, start + tt.calendar.HoursBeforeDay(day.date) + hours/day.hours*tt.calendar.HoursPerDay()
+		}
+		hours -= day.hours
+	}
+	last := days[len(days)-1]
+	return last.date, start + tt.calendar.HoursBeforeDay(last.date) + tt.calendar.HoursPerDay()
+}
+
+// offsetAt is the inverse of locate: it returns how many hours of a week a
+// developer can work before the given team hour.
+func (tt *Timetable) offsetAt(dev, week int, hour float64) float64 {
+	start := tt.calendar.HoursBefore(week)
+	if hour <= start {
+		return 0
+	}
+	offset := 0.0
+	for _, day := range tt.Days(dev, week) {
+		dayStart := start + tt.calendar.HoursBeforeDay(day.date)
+		if hour <= dayStart {
+			return offset
+		}
+		if hour < dayStart+tt.calendar.HoursPerDay() {
+			return offset + (hour-dayStart)/tt.calendar.HoursPerDay()*day.hours
+		}
+		offset += day.hours
+	}
+	return offset
+}
+
+// moment converts hours a developer has worked in a week into a timestamp.
+// Work starts every day at the calendar's start hour. A start that falls on
+// the end of a day moves to the beginning of the next available day.
+func (tt *Timetable) moment(dev, week int, hours float64, start bool) time.Time {
+	days := tt.Days(dev, week)
+	if len(days) == 0 {
+		return tt.calendar.WeekStart(week)
+	}
+	for i, day := range days {
+		last := i == len(days)-1
+		if hours < day.hours-epsilon || (!start && hours <= day.hours+epsilon) || last {
+			hours = math.Min(hours, day.hours)
+			return tt.calendar.DayStart(day.date).Add(time.Duration(hours * float64(time.Hour)))
+		}
+		hours -= day.hours
+	}
+	return tt.calendar.WeekStart(week)
+}
+
+// lastWeek returns the last week in which a developer has booked work, or -1.
+func (tt *Timetable) lastWeek(dev int) int {
+	for week := len(tt.weeks) - 1; week >= 0; week-- {
+		if len(tt.weeks[week].bookings[dev]) > 0 {
+			return week
+		}
+	}
+	return -1
+}